@@ -92,3 +92,76 @@ func TestClipMode_String(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"beamlattice", func() spec.Cloner {
+			return &BeamLattice{ClipMode: ClipInside, ClippingMeshID: 1, Radius: 1, MinLength: 0.1,
+				Beams: []Beam{{Indices: [2]uint32{0, 1}, Radius: [2]float32{1, 2}}}, BeamSets: []BeamSet{{Name: "a", Refs: []uint32{0}}}}
+		}, func(c interface{}) {
+			c.(*BeamLattice).Beams[0].Radius[0] = 3
+			c.(*BeamLattice).BeamSets[0].Name = "b"
+			c.(*BeamLattice).BeamSets[0].Refs[0] = 1
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"beamlattice", &BeamLattice{ClippingMeshID: 1, RepresentationMeshID: 2}, &BeamLattice{ClippingMeshID: 11, RepresentationMeshID: 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name string
+		v    spec.Scaler
+		want interface{}
+	}{
+		{"beamlattice", &BeamLattice{MinLength: 1, Radius: 2, Beams: []Beam{{Radius: [2]float32{1, 3}}}}, &BeamLattice{MinLength: 2, Radius: 4, Beams: []Beam{{Radius: [2]float32{2, 6}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.v.Scale(2)
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T.Scale() = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
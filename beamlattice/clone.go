@@ -0,0 +1,15 @@
+package beamlattice
+
+// Clone returns a deep copy of m.
+func (m *BeamLattice) Clone() interface{} {
+	c := *m
+	c.Beams = append([]Beam(nil), m.Beams...)
+	if m.BeamSets != nil {
+		c.BeamSets = make([]BeamSet, len(m.BeamSets))
+		for i, set := range m.BeamSets {
+			c.BeamSets[i] = set
+			c.BeamSets[i].Refs = append([]uint32(nil), set.Refs...)
+		}
+	}
+	return &c
+}
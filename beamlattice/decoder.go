@@ -14,8 +14,8 @@ func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
 }
 
 func (Spec) CreateElementDecoder(parent interface{}, name string) spec.ElementDecoder {
-	if name == attrBeamLattice {
-		return &beamLatticeDecoder{mesh: parent.(*go3mf.Mesh)}
+	if mesh, ok := parent.(*go3mf.Mesh); ok && name == attrBeamLattice {
+		return &beamLatticeDecoder{mesh: mesh}
 	}
 	return nil
 }
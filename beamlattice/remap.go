@@ -0,0 +1,7 @@
+package beamlattice
+
+// RemapIDs replaces the clipping and representation mesh IDs with the ones returned by fn.
+func (m *BeamLattice) RemapIDs(fn func(uint32) uint32) {
+	m.ClippingMeshID = fn(m.ClippingMeshID)
+	m.RepresentationMeshID = fn(m.RepresentationMeshID)
+}
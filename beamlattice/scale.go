@@ -0,0 +1,11 @@
+package beamlattice
+
+// Scale multiplies the radii and the minimum length by factor.
+func (m *BeamLattice) Scale(factor float32) {
+	m.MinLength *= factor
+	m.Radius *= factor
+	for i := range m.Beams {
+		m.Beams[i].Radius[0] *= factor
+		m.Beams[i].Radius[1] *= factor
+	}
+}
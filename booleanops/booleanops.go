@@ -0,0 +1,104 @@
+package booleanops
+
+import (
+	"errors"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Namespace is the canonical name of this extension.
+const Namespace = "http://schemas.3mf.io/3dmanufacturing/booleanoperations/2023/07"
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "bo",
+	IsRequired: false,
+}
+
+var (
+	ErrBooleanObjType   = errors.New("booleanshape MUST only be added to an object of type model")
+	ErrBooleanShapeOnly = errors.New("an object with a booleanshape MUST NOT contain a mesh or components")
+	ErrBaseObjectRef    = errors.New("base object MUST reference a mesh or booleanshape object of type model")
+	ErrOperandRef       = errors.New("operands MUST reference mesh objects of type model")
+	ErrMissingOperands  = errors.New("booleanshape MUST contain at least one boolean operand")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+type Spec struct{}
+
+// Operation defines the boolean operation applied to the operands.
+type Operation uint8
+
+// Supported operations.
+const (
+	OperationUnion Operation = iota
+	OperationDifference
+	OperationIntersection
+)
+
+func newOperation(s string) (o Operation, ok bool) {
+	o, ok = map[string]Operation{
+		"union":        OperationUnion,
+		"difference":   OperationDifference,
+		"intersection": OperationIntersection,
+	}[s]
+	return
+}
+
+func (o Operation) String() string {
+	return map[Operation]string{
+		OperationUnion:        "union",
+		OperationDifference:   "difference",
+		OperationIntersection: "intersection",
+	}[o]
+}
+
+// Boolean defines an operand of a boolean shape.
+type Boolean struct {
+	ObjectID  uint32
+	Transform go3mf.Matrix
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (b *Boolean) HasTransform() bool {
+	return hasTransform(b.Transform)
+}
+
+// BooleanShape defines an object as the result of applying Operation
+// to the base object and each of the operands, in order.
+type BooleanShape struct {
+	ObjectID  uint32
+	Operation Operation
+	Transform go3mf.Matrix
+	Operands  []Boolean
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (b *BooleanShape) HasTransform() bool {
+	return hasTransform(b.Transform)
+}
+
+// GetBooleanShape returns the boolean shape of the object, if any.
+func GetBooleanShape(obj *go3mf.Object) *BooleanShape {
+	for _, a := range obj.Any {
+		if a, ok := a.(*BooleanShape); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+func hasTransform(t go3mf.Matrix) bool {
+	return t != go3mf.Matrix{} && t != go3mf.Identity()
+}
+
+const (
+	attrBooleanShape = "booleanshape"
+	attrBoolean      = "boolean"
+	attrObjectID     = "objectid"
+	attrOperation    = "operation"
+	attrTransform    = "transform"
+)
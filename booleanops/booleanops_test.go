@@ -0,0 +1,123 @@
+package booleanops
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+var _ spec.Marshaler = new(BooleanShape)
+
+func TestOperation_String(t *testing.T) {
+	tests := []struct {
+		name string
+		o    Operation
+	}{
+		{"union", OperationUnion},
+		{"difference", OperationDifference},
+		{"intersection", OperationIntersection},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.String(); got != tt.name {
+				t.Errorf("Operation.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantO  Operation
+		wantOk bool
+	}{
+		{"union", OperationUnion, true},
+		{"difference", OperationDifference, true},
+		{"intersection", OperationIntersection, true},
+		{"empty", OperationUnion, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotO, gotOk := newOperation(tt.name)
+			if !reflect.DeepEqual(gotO, tt.wantO) {
+				t.Errorf("newOperation() gotO = %v, want %v", gotO, tt.wantO)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newOperation() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetBooleanShape(t *testing.T) {
+	shape := new(BooleanShape)
+	tests := []struct {
+		name string
+		obj  *go3mf.Object
+		want *BooleanShape
+	}{
+		{"empty", &go3mf.Object{}, nil},
+		{"other", &go3mf.Object{Any: go3mf.Any{nil}}, nil},
+		{"base", &go3mf.Object{Any: go3mf.Any{nil, shape}}, shape},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetBooleanShape(tt.obj); got != tt.want {
+				t.Errorf("GetBooleanShape() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"shape", func() spec.Cloner {
+			return &BooleanShape{ObjectID: 1, Operation: OperationDifference, Transform: go3mf.Identity(), Operands: []Boolean{{ObjectID: 2}}}
+		}, func(c interface{}) { c.(*BooleanShape).Operands[0].ObjectID = 3 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"shape", &BooleanShape{ObjectID: 1, Operands: []Boolean{{ObjectID: 2}}}, &BooleanShape{ObjectID: 11, Operands: []Boolean{{ObjectID: 12}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
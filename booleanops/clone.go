@@ -0,0 +1,8 @@
+package booleanops
+
+// Clone returns a deep copy of b.
+func (b *BooleanShape) Clone() interface{} {
+	c := *b
+	c.Operands = append([]Boolean(nil), b.Operands...)
+	return &c
+}
@@ -0,0 +1,112 @@
+package booleanops
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	if parent, ok := parent.(*go3mf.Object); ok && name == attrBooleanShape {
+		child = &booleanShapeDecoder{resource: parent}
+	}
+	return
+}
+
+type booleanShapeDecoder struct {
+	baseDecoder
+	resource *go3mf.Object
+	shape    *BooleanShape
+}
+
+func (d *booleanShapeDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.shape = new(BooleanShape)
+	d.resource.Any = append(d.resource.Any, d.shape)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.shape.ObjectID = uint32(val)
+		case attrOperation:
+			var ok bool
+			if d.shape.Operation, ok = newOperation(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTransform:
+			var ok bool
+			if d.shape.Transform, ok = spec.ParseMatrix(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.Wrap(errs, d.shape)
+	}
+	return nil
+}
+
+func (d *booleanShapeDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.shape)
+}
+
+func (d *booleanShapeDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrBoolean {
+		child = &booleanDecoder{shape: d.shape}
+	}
+	return
+}
+
+type booleanDecoder struct {
+	baseDecoder
+	shape *BooleanShape
+}
+
+func (d *booleanDecoder) Start(attrs []spec.Attr) error {
+	var (
+		b    Boolean
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrObjectID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			b.ObjectID = uint32(val)
+		case attrTransform:
+			var ok bool
+			if b.Transform, ok = spec.ParseMatrix(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		}
+	}
+	d.shape.Operands = append(d.shape.Operands, b)
+	if errs != nil {
+		return specerr.WrapIndex(errs, b, len(d.shape.Operands)-1)
+	}
+	return nil
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
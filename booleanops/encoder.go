@@ -0,0 +1,35 @@
+package booleanops
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf/spec"
+)
+
+// Marshal3MF encodes the resource.
+func (b *BooleanShape) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBooleanShape}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(b.ObjectID), 10)},
+	}}
+	if b.Operation != OperationUnion {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrOperation}, Value: b.Operation.String()})
+	}
+	if b.HasTransform() {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: b.Transform.String()})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, op := range b.Operands {
+		xb := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrBoolean}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrObjectID}, Value: strconv.FormatUint(uint64(op.ObjectID), 10)},
+		}}
+		if op.HasTransform() {
+			xb.Attr = append(xb.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: op.Transform.String()})
+		}
+		x.EncodeToken(xb)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
@@ -0,0 +1,43 @@
+package booleanops
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestMarshalModel(t *testing.T) {
+	shape := &BooleanShape{ObjectID: 1, Operation: OperationIntersection, Transform: go3mf.Identity().Translate(1, 0, 0), Operands: []Boolean{
+		{ObjectID: 2, Transform: go3mf.Identity().Translate(0, 2, 0)},
+		{ObjectID: 2},
+	}}
+	union := &BooleanShape{ObjectID: 3, Operands: []Boolean{{ObjectID: 2}}}
+	m := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Objects: []*go3mf.Object{
+				{ID: 3, Name: "Shape", Any: go3mf.Any{shape}},
+				{ID: 4, Name: "Union", Any: go3mf.Any{union}},
+			},
+		},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("booleanops.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("booleanops.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("booleanops.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
@@ -0,0 +1,9 @@
+package booleanops
+
+// RemapIDs replaces the object IDs with the ones returned by fn.
+func (b *BooleanShape) RemapIDs(fn func(uint32) uint32) {
+	b.ObjectID = fn(b.ObjectID)
+	for i := range b.Operands {
+		b.Operands[i].ObjectID = fn(b.Operands[i].ObjectID)
+	}
+}
@@ -0,0 +1,83 @@
+package booleanops
+
+import (
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func (Spec) Validate(model interface{}, path string, element interface{}) error {
+	if obj, ok := element.(*go3mf.Object); ok {
+		return validateObject(model.(*go3mf.Model), path, obj)
+	}
+	return nil
+}
+
+func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	shape := GetBooleanShape(obj)
+	if shape == nil {
+		return nil
+	}
+	var errs, sErrs error
+	if obj.Type != go3mf.ObjectTypeModel {
+		errs = errors.Append(errs, ErrBooleanObjType)
+	}
+	if obj.Mesh != nil || len(obj.Components) > 0 {
+		errs = errors.Append(errs, ErrBooleanShapeOnly)
+	}
+	if shape.ObjectID == 0 {
+		sErrs = errors.Append(sErrs, errors.NewMissingFieldError(attrObjectID))
+	} else if base, ok := m.FindObject(path, shape.ObjectID); ok {
+		if isRecursive(m, path, obj.ID, base) {
+			sErrs = errors.Append(sErrs, errors.ErrRecursion)
+		} else if base.Type != go3mf.ObjectTypeModel || (base.Mesh == nil && GetBooleanShape(base) == nil) {
+			sErrs = errors.Append(sErrs, ErrBaseObjectRef)
+		}
+	} else {
+		sErrs = errors.Append(sErrs, errors.ErrMissingResource)
+	}
+	if len(shape.Operands) == 0 {
+		sErrs = errors.Append(sErrs, ErrMissingOperands)
+	}
+	for i, op := range shape.Operands {
+		var opErr error
+		if op.ObjectID == 0 {
+			opErr = errors.NewMissingFieldError(attrObjectID)
+		} else if ref, ok := m.FindObject(path, op.ObjectID); ok {
+			if ref.ID == obj.ID {
+				opErr = errors.ErrRecursion
+			} else if ref.Type != go3mf.ObjectTypeModel || ref.Mesh == nil {
+				opErr = ErrOperandRef
+			}
+		} else {
+			opErr = errors.ErrMissingResource
+		}
+		if opErr != nil {
+			sErrs = errors.Append(sErrs, errors.WrapIndex(opErr, op, i))
+		}
+	}
+	if sErrs != nil {
+		errs = errors.Append(errs, errors.Wrap(sErrs, shape))
+	}
+	return errs
+}
+
+// isRecursive reports whether following the chain of base objects
+// starting at base leads back to the object with the given id.
+func isRecursive(m *go3mf.Model, path string, id uint32, base *go3mf.Object) bool {
+	visited := make(map[uint32]struct{})
+	for base != nil {
+		if base.ID == id {
+			return true
+		}
+		if _, ok := visited[base.ID]; ok {
+			return false
+		}
+		visited[base.ID] = struct{}{}
+		shape := GetBooleanShape(base)
+		if shape == nil {
+			return false
+		}
+		base, _ = m.FindObject(path, shape.ObjectID)
+	}
+	return false
+}
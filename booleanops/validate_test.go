@@ -0,0 +1,84 @@
+package booleanops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestValidate(t *testing.T) {
+	validMesh := func() *go3mf.Mesh {
+		return &go3mf.Mesh{
+			Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+			Triangles: []go3mf.Triangle{
+				go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 1, 3),
+				go3mf.NewTriangle(0, 2, 3), go3mf.NewTriangle(1, 2, 3),
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []string
+	}{
+		{"empty shape", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Any: go3mf.Any{&BooleanShape{}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@BooleanShape: %v", &errors.MissingFieldError{Name: attrObjectID}),
+			fmt.Sprintf("Resources@Object#0@BooleanShape: %v", ErrMissingOperands),
+		}},
+		{"invalid object", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: validMesh()},
+			{ID: 2, Type: go3mf.ObjectTypeSupport, Any: go3mf.Any{&BooleanShape{ObjectID: 1, Operands: []Boolean{{ObjectID: 1}}}}},
+			{ID: 3, Mesh: validMesh(), Any: go3mf.Any{&BooleanShape{ObjectID: 1, Operands: []Boolean{{ObjectID: 1}}}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#1: %v", ErrBooleanObjType),
+			fmt.Sprintf("Resources@Object#2: %v", ErrBooleanShapeOnly),
+		}},
+		{"invalid references", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Mesh: validMesh()},
+			{ID: 2, Type: go3mf.ObjectTypeSupport, Mesh: validMesh()},
+			{ID: 3, Components: []*go3mf.Component{{ObjectID: 1}}},
+			{ID: 4, Any: go3mf.Any{&BooleanShape{ObjectID: 100, Operands: []Boolean{
+				{}, {ObjectID: 100}, {ObjectID: 2}, {ObjectID: 3}, {ObjectID: 4}, {ObjectID: 1},
+			}}}},
+			{ID: 5, Any: go3mf.Any{&BooleanShape{ObjectID: 2, Operands: []Boolean{{ObjectID: 1}}}}},
+			{ID: 6, Any: go3mf.Any{&BooleanShape{ObjectID: 3, Operands: []Boolean{{ObjectID: 1}}}}},
+			{ID: 7, Any: go3mf.Any{&BooleanShape{ObjectID: 8, Operands: []Boolean{{ObjectID: 1}}}}},
+			{ID: 8, Any: go3mf.Any{&BooleanShape{ObjectID: 7, Operands: []Boolean{{ObjectID: 1}}}}},
+			{ID: 9, Any: go3mf.Any{&BooleanShape{ObjectID: 9, Operands: []Boolean{{ObjectID: 1}}}}},
+			{ID: 10, Any: go3mf.Any{&BooleanShape{ObjectID: 5, Operands: []Boolean{{ObjectID: 1}}}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#3@BooleanShape: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@Object#3@BooleanShape@Boolean#0: %v", &errors.MissingFieldError{Name: attrObjectID}),
+			fmt.Sprintf("Resources@Object#3@BooleanShape@Boolean#1: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@Object#3@BooleanShape@Boolean#2: %v", ErrOperandRef),
+			fmt.Sprintf("Resources@Object#3@BooleanShape@Boolean#3: %v", ErrOperandRef),
+			fmt.Sprintf("Resources@Object#3@BooleanShape@Boolean#4: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#4@BooleanShape: %v", ErrBaseObjectRef),
+			fmt.Sprintf("Resources@Object#5@BooleanShape: %v", ErrBaseObjectRef),
+			fmt.Sprintf("Resources@Object#6@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#7@BooleanShape: %v", errors.ErrRecursion),
+			fmt.Sprintf("Resources@Object#8@BooleanShape: %v", errors.ErrRecursion),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.Extensions = []go3mf.Extension{DefaultExtension}
+			err := tt.model.Validate()
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			var errs []string
+			for _, err := range err.(*errors.List).Errors {
+				errs = append(errs, err.Error())
+			}
+			if diff := deep.Equal(errs, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package go3mf
+
+import (
+	"math"
+	"sort"
+)
+
+const bvhLeafSize = 4
+
+// bvhNode is a node of a bounding volume hierarchy.
+// Leaves have no children and contain the triangles order[start:end].
+type bvhNode struct {
+	box         Box
+	left, right int
+	start, end  int
+}
+
+func (n *bvhNode) isLeaf() bool {
+	return n.left == 0
+}
+
+// BVH is a bounding volume hierarchy over the triangles of a mesh
+// that accelerates ray casting, closest point and inside queries.
+//
+// Triangles that reference undefined vertices are ignored.
+// The BVH has to be created again if the mesh vertices or triangles change.
+type BVH struct {
+	mesh  *Mesh
+	nodes []bvhNode
+	order []uint32
+	boxes []Box // indexed by triangle.
+}
+
+// NewBVH returns a new BVH over the triangles of m.
+func NewBVH(m *Mesh) *BVH {
+	b := &BVH{mesh: m, boxes: make([]Box, len(m.Triangles))}
+	centers := make([]Point3D, len(m.Triangles))
+	for i, t := range m.Triangles {
+		v1, v2, v3 := t.Indices()
+		n := uint32(len(m.Vertices))
+		if v1 >= n || v2 >= n || v3 >= n {
+			continue
+		}
+		box := newLimitBox().extendPoint(m.Vertices[v1]).extendPoint(m.Vertices[v2]).extendPoint(m.Vertices[v3])
+		b.boxes[i] = box
+		centers[i] = Point3D{(box.Min[0] + box.Max[0]) / 2, (box.Min[1] + box.Max[1]) / 2, (box.Min[2] + box.Max[2]) / 2}
+		b.order = append(b.order, uint32(i))
+	}
+	if len(b.order) > 0 {
+		b.build(0, len(b.order), centers)
+	}
+	return b
+}
+
+// build creates the node that contains order[start:end] and returns its index.
+func (b *BVH) build(start, end int, centers []Point3D) int {
+	idx := len(b.nodes)
+	b.nodes = append(b.nodes, bvhNode{start: start, end: end})
+	box, cbox := newLimitBox(), newLimitBox()
+	for _, t := range b.order[start:end] {
+		box = box.extend(b.boxes[t])
+		cbox = cbox.extendPoint(centers[t])
+	}
+	b.nodes[idx].box = box
+	if end-start <= bvhLeafSize {
+		return idx
+	}
+	axis := 0
+	for i := 1; i < 3; i++ {
+		if cbox.Max[i]-cbox.Min[i] > cbox.Max[axis]-cbox.Min[axis] {
+			axis = i
+		}
+	}
+	tris := b.order[start:end]
+	sort.Slice(tris, func(i, j int) bool { return centers[tris[i]][axis] < centers[tris[j]][axis] })
+	mid := (start + end) / 2
+	left := b.build(start, mid, centers)
+	right := b.build(mid, end, centers)
+	b.nodes[idx].left, b.nodes[idx].right = left, right
+	return idx
+}
+
+// overlapping calls fn for every triangle whose bounding box overlaps box.
+func (b *BVH) overlapping(box Box, fn func(t uint32)) {
+	if len(b.nodes) == 0 {
+		return
+	}
+	stack := []int{0}
+	for len(stack) > 0 {
+		n := &b.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !n.box.overlaps(box) {
+			continue
+		}
+		if n.isLeaf() {
+			for _, t := range b.order[n.start:n.end] {
+				if b.boxes[t].overlaps(box) {
+					fn(t)
+				}
+			}
+			continue
+		}
+		stack = append(stack, n.left, n.right)
+	}
+}
+
+func (b Box) overlaps(o Box) bool {
+	return b.Min[0] <= o.Max[0] && o.Min[0] <= b.Max[0] &&
+		b.Min[1] <= o.Max[1] && o.Min[1] <= b.Max[1] &&
+		b.Min[2] <= o.Max[2] && o.Min[2] <= b.Max[2]
+}
+
+// SurfacePoint defines a point on a mesh triangle.
+type SurfacePoint struct {
+	Triangle uint32
+	Point    Point3D
+	Distance float32
+}
+
+// Raycast returns the first triangle hit by the ray that starts at origin
+// and goes along dir, which does not need to be normalized.
+// Distance is the ray parameter of the hit, which is the distance
+// to origin when dir is normalized. It returns false if no triangle is hit.
+// Hits at origin are ignored, so the ray can start on the mesh surface.
+func (b *BVH) Raycast(origin, dir Point3D) (SurfacePoint, bool) {
+	o, d := toFloat64(origin), toFloat64(dir)
+	best, found := math.Inf(1), false
+	var hit SurfacePoint
+	b.raycast(o, d, func(tri uint32, t float64) float64 {
+		if t < best {
+			best, found = t, true
+			hit.Triangle = tri
+		}
+		return best
+	})
+	if !found {
+		return SurfacePoint{}, false
+	}
+	hit.Distance = float32(best)
+	hit.Point = Point3D{float32(o[0] + d[0]*best), float32(o[1] + d[1]*best), float32(o[2] + d[2]*best)}
+	return hit, true
+}
+
+// raycast calls fn for every triangle hit by the ray with the ray parameter of the hit.
+// fn returns the maximum ray parameter of the hits still of interest.
+func (b *BVH) raycast(o, d [3]float64, fn func(tri uint32, t float64) float64) {
+	if len(b.nodes) == 0 || d == ([3]float64{}) {
+		return
+	}
+	r := newRay(o, d)
+	var inv [3]float64
+	for i := range d {
+		inv[i] = 1 / d[i]
+	}
+	maxT := math.Inf(1)
+	stack := []int{0}
+	for len(stack) > 0 {
+		n := &b.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if tmin, ok := rayBox(o, inv, n.box); !ok || tmin > maxT {
+			continue
+		}
+		if !n.isLeaf() {
+			stack = append(stack, n.left, n.right)
+			continue
+		}
+		for _, tri := range b.order[n.start:n.end] {
+			if t, ok := b.rayTriangle(&r, tri); ok {
+				maxT = fn(tri, t)
+			}
+		}
+	}
+}
+
+// rayBox implements the slab test and returns the ray parameter where the ray enters the box.
+func rayBox(o, inv [3]float64, box Box) (float64, bool) {
+	tmin, tmax := 0.0, math.Inf(1)
+	for i := 0; i < 3; i++ {
+		t1 := (float64(box.Min[i]) - o[i]) * inv[i]
+		t2 := (float64(box.Max[i]) - o[i]) * inv[i]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		// NaN appears when the ray is parallel and starts on the slab boundary.
+		if !math.IsNaN(t1) {
+			tmin = math.Max(tmin, t1)
+		}
+		if !math.IsNaN(t2) {
+			tmax = math.Min(tmax, t2)
+		}
+		if tmin > tmax {
+			return 0, false
+		}
+	}
+	return tmin, true
+}
+
+// ray defines the transform of the watertight ray triangle test by Woop et al.,
+// which moves the ray origin to zero and shears the ray direction into the z axis.
+type ray struct {
+	o          [3]float64
+	kx, ky, kz int
+	sx, sy, sz float64
+}
+
+func newRay(o, d [3]float64) ray {
+	kz := largestAxis(d)
+	kx, ky := (kz+1)%3, (kz+2)%3
+	return ray{o: o, kx: kx, ky: ky, kz: kz, sx: d[kx] / d[kz], sy: d[ky] / d[kz], sz: 1 / d[kz]}
+}
+
+// project returns v in the ray space, where z is the ray parameter.
+func (r *ray) project(v Point3D) [3]float64 {
+	p := sub64(toFloat64(v), r.o)
+	return [3]float64{p[r.kx] - r.sx*p[r.kz], p[r.ky] - r.sy*p[r.kz], r.sz * p[r.kz]}
+}
+
+// edgeFunction returns the edge function of the projected edge pq at the ray
+// and its sign. Ties are broken as if the ray was moved by an infinitesimal (ε, ε²),
+// which only depends on the edge, so an edge or vertex shared by several triangles
+// is hit by exactly one of them when they surround it.
+func edgeFunction(p, q [3]float64) (float64, float64) {
+	e := p[0]*q[1] - p[1]*q[0]
+	switch {
+	case e != 0:
+		return e, e
+	case p[1] != q[1]:
+		return e, p[1] - q[1]
+	default:
+		return e, q[0] - p[0]
+	}
+}
+
+// rayTriangle returns the ray parameter of the hit with triangle tri.
+// The edge functions are computed from the projected vertices alone,
+// so the triangles that share an edge see it with the opposite sign.
+func (b *BVH) rayTriangle(r *ray, tri uint32) (float64, bool) {
+	v1, v2, v3 := b.mesh.Triangles[tri].Indices()
+	p0, p1, p2 := r.project(b.mesh.Vertices[v1]), r.project(b.mesh.Vertices[v2]), r.project(b.mesh.Vertices[v3])
+	u, su := edgeFunction(p1, p2)
+	v, sv := edgeFunction(p2, p0)
+	w, sw := edgeFunction(p0, p1)
+	if !(su > 0 && sv > 0 && sw > 0) && !(su < 0 && sv < 0 && sw < 0) {
+		return 0, false
+	}
+	det := u + v + w
+	if det == 0 {
+		return 0, false
+	}
+	tu, tv, tw := u*p0[2], v*p1[2], w*p2[2]
+	t := (tu + tv + tw) / det
+	// Reject the hits at the ray origin, whose parameter only differs from zero by rounding.
+	if t <= 0 || math.Abs(tu+tv+tw) <= 1e-12*(math.Abs(tu)+math.Abs(tv)+math.Abs(tw)) {
+		return 0, false
+	}
+	return t, true
+}
+
+// ClosestPoint returns the point of the mesh surface closest to p.
+// It returns false if the mesh has no triangles.
+func (b *BVH) ClosestPoint(p Point3D) (SurfacePoint, bool) {
+	if len(b.nodes) == 0 {
+		return SurfacePoint{}, false
+	}
+	q := toFloat64(p)
+	best := math.Inf(1)
+	var hit SurfacePoint
+	stack := []int{0}
+	for len(stack) > 0 {
+		n := &b.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if boxDistance2(q, n.box) > best {
+			continue
+		}
+		if !n.isLeaf() {
+			stack = append(stack, n.left, n.right)
+			continue
+		}
+		for _, tri := range b.order[n.start:n.end] {
+			v1, v2, v3 := b.mesh.Triangles[tri].Indices()
+			c := closestPointTriangle(q, toFloat64(b.mesh.Vertices[v1]), toFloat64(b.mesh.Vertices[v2]), toFloat64(b.mesh.Vertices[v3]))
+			if d := sub64(c, q); dot64(d, d) < best {
+				best = dot64(d, d)
+				hit = SurfacePoint{Triangle: tri, Point: Point3D{float32(c[0]), float32(c[1]), float32(c[2])}}
+			}
+		}
+	}
+	hit.Distance = float32(math.Sqrt(best))
+	return hit, true
+}
+
+func boxDistance2(p [3]float64, box Box) float64 {
+	var d2 float64
+	for i := 0; i < 3; i++ {
+		if v := float64(box.Min[i]) - p[i]; v > 0 {
+			d2 += v * v
+		} else if v := p[i] - float64(box.Max[i]); v > 0 {
+			d2 += v * v
+		}
+	}
+	return d2
+}
+
+// closestPointTriangle returns the point of the triangle abc closest to p,
+// as described in Real-Time Collision Detection by Christer Ericson.
+func closestPointTriangle(p, a, b, c [3]float64) [3]float64 {
+	lerp := func(x, y [3]float64, t float64) [3]float64 {
+		return [3]float64{x[0] + (y[0]-x[0])*t, x[1] + (y[1]-x[1])*t, x[2] + (y[2]-x[2])*t}
+	}
+	ab, ac, ap := sub64(b, a), sub64(c, a), sub64(p, a)
+	d1, d2 := dot64(ab, ap), dot64(ac, ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a
+	}
+	bp := sub64(p, b)
+	d3, d4 := dot64(ab, bp), dot64(ac, bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return lerp(a, b, d1/(d1-d3))
+	}
+	cp := sub64(p, c)
+	d5, d6 := dot64(ab, cp), dot64(ac, cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return lerp(a, c, d2/(d2-d6))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		return lerp(b, c, (d4-d3)/((d4-d3)+(d5-d6)))
+	}
+	denom := 1 / (va + vb + vc)
+	v, w := vb*denom, vc*denom
+	return [3]float64{a[0] + ab[0]*v + ac[0]*w, a[1] + ab[1]*v + ac[1]*w, a[2] + ab[2]*v + ac[2]*w}
+}
+
+// insideDirections are far from the axes, the diagonals and each other,
+// so the rays are unlikely to be parallel to the mesh faces.
+var insideDirections = [...][3]float64{
+	{0.5017072973857111, 0.3219046821376528, 0.8029116784352948},
+	{-0.7193173861113361, 0.613114819164271, 0.3266078942082057},
+	{0.26869485857307135, -0.8422838830520952, -0.4672910584711434},
+}
+
+// Contains returns true if p is inside the mesh, which must be closed.
+// It counts the triangles crossed by three rays from p, where an edge or vertex
+// shared by several triangles is only counted once, and takes the majority
+// of their parities, so a single ray affected by rounding does not change the result.
+// It does not depend on the orientation of the triangles.
+func (b *BVH) Contains(p Point3D) bool {
+	o := toFloat64(p)
+	var votes int
+	for _, d := range insideDirections {
+		var count int
+		b.raycast(o, d, func(uint32, float64) float64 {
+			count++
+			return math.Inf(1)
+		})
+		votes += count % 2
+	}
+	return votes > len(insideDirections)/2
+}
+
+// SignedDistance returns the distance from p to the mesh surface,
+// which is negative if p is inside the mesh. The mesh must be closed.
+// It returns false if the mesh has no triangles.
+func (b *BVH) SignedDistance(p Point3D) (float32, bool) {
+	hit, ok := b.ClosestPoint(p)
+	if !ok {
+		return 0, false
+	}
+	if b.Contains(p) {
+		return -hit.Distance, true
+	}
+	return hit.Distance, true
+}
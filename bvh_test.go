@@ -0,0 +1,185 @@
+package go3mf
+
+import (
+	"math"
+	"testing"
+)
+
+func near32(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-5
+}
+
+func pointNear(a, b Point3D) bool {
+	return near32(a[0], b[0]) && near32(a[1], b[1]) && near32(a[2], b[2])
+}
+
+func TestBVH_Raycast(t *testing.T) {
+	b := NewBVH(newCube(Point3D{}, 1))
+	tests := []struct {
+		name        string
+		origin, dir Point3D
+		want        SurfacePoint
+		wantOk      bool
+	}{
+		{"outside", Point3D{0.25, 0.25, -1}, Point3D{0, 0, 1}, SurfacePoint{Triangle: 0, Point: Point3D{0.25, 0.25, 0}, Distance: 1}, true},
+		{"miss", Point3D{0.25, 0.25, -1}, Point3D{0, 0, -1}, SurfacePoint{}, false},
+		{"parallel", Point3D{2, 0.5, 0.5}, Point3D{0, 1, 0}, SurfacePoint{}, false},
+		{"inside", Point3D{0.25, 0.25, 0.75}, Point3D{2, 0, 0}, SurfacePoint{Triangle: 11, Point: Point3D{1, 0.25, 0.75}, Distance: 0.375}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.Raycast(tt.origin, tt.dir)
+			if ok != tt.wantOk {
+				t.Fatalf("BVH.Raycast() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got.Triangle != tt.want.Triangle || !pointNear(got.Point, tt.want.Point) || !near32(got.Distance, tt.want.Distance) {
+				t.Errorf("BVH.Raycast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBVH_ClosestPoint(t *testing.T) {
+	b := NewBVH(newCube(Point3D{}, 1))
+	tests := []struct {
+		name     string
+		p        Point3D
+		want     Point3D
+		distance float32
+	}{
+		{"face", Point3D{0.5, 0.5, 2}, Point3D{0.5, 0.5, 1}, 1},
+		{"edge", Point3D{2, 0.5, 2}, Point3D{1, 0.5, 1}, float32(math.Sqrt2)},
+		{"corner", Point3D{2, 2, 2}, Point3D{1, 1, 1}, float32(math.Sqrt(3))},
+		{"inside", Point3D{0.5, 0.5, 0.2}, Point3D{0.5, 0.5, 0}, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.ClosestPoint(tt.p)
+			if !ok {
+				t.Fatal("BVH.ClosestPoint() not found")
+			}
+			if !pointNear(got.Point, tt.want) || !near32(got.Distance, tt.distance) {
+				t.Errorf("BVH.ClosestPoint() = %v, want %v at %v", got, tt.want, tt.distance)
+			}
+		})
+	}
+}
+
+func TestBVH_SignedDistance(t *testing.T) {
+	// Two separated cubes to exercise the hierarchy.
+	m := newCube(Point3D{}, 1)
+	other := newCube(Point3D{3, 0, 0}, 1)
+	for _, tr := range other.Triangles {
+		v1, v2, v3 := tr.Indices()
+		m.Triangles = append(m.Triangles, NewTriangle(v1+8, v2+8, v3+8))
+	}
+	m.Vertices = append(m.Vertices, other.Vertices...)
+	b := NewBVH(m)
+	tests := []struct {
+		name   string
+		p      Point3D
+		inside bool
+		want   float32
+	}{
+		{"center", Point3D{0.5, 0.5, 0.5}, true, -0.5},
+		{"near-face", Point3D{0.5, 0.5, 0.9}, true, -0.1},
+		{"other", Point3D{3.5, 0.5, 0.75}, true, -0.25},
+		{"between", Point3D{2, 0.5, 0.5}, false, 1},
+		{"above", Point3D{0.5, 0.5, 1.5}, false, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Contains(tt.p); got != tt.inside {
+				t.Errorf("BVH.Contains() = %v, want %v", got, tt.inside)
+			}
+			if got, ok := b.SignedDistance(tt.p); !ok || !near32(got, tt.want) {
+				t.Errorf("BVH.SignedDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBVH_Contains_DiagonalEdges(t *testing.T) {
+	// A unit cube whose top and bottom faces are split along x == y,
+	// so rays from the x == y plane can hit the shared edges.
+	m := newCube(Point3D{}, 1)
+	m.Triangles[0], m.Triangles[1] = NewTriangle(0, 2, 3), NewTriangle(0, 3, 1)
+	m.Triangles[2], m.Triangles[3] = NewTriangle(4, 5, 7), NewTriangle(4, 7, 6)
+	b := NewBVH(m)
+	for i := 1; i < 20; i++ {
+		for j := 1; j < 20; j++ {
+			p := Point3D{float32(i) * 0.05, float32(i) * 0.05, float32(j) * 0.05}
+			if !b.Contains(p) {
+				t.Errorf("BVH.Contains(%v) = false, want true", p)
+			}
+			if d, ok := b.SignedDistance(p); !ok || d > 0 {
+				t.Errorf("BVH.SignedDistance(%v) = %v, want negative", p, d)
+			}
+		}
+	}
+	for _, p := range []Point3D{{-0.5, -0.5, 0.5}, {1.5, 1.5, 0.5}, {0.5, 0.5, 1.5}, {0.5, 0.5, -0.5}} {
+		if b.Contains(p) {
+			t.Errorf("BVH.Contains(%v) = true, want false", p)
+		}
+	}
+}
+
+func TestBVH_Raycast_SharedEdges(t *testing.T) {
+	b := NewBVH(newCube(Point3D{}, 1))
+	hits := []struct {
+		name string
+		o, d [3]float64
+		want int
+	}{
+		{"vertex", [3]float64{0.5, 0.5, 0.5}, [3]float64{1, 1, 1}, 1},
+		{"edge", [3]float64{0.5, 0.5, 0.5}, [3]float64{1, 1, 0}, 1},
+		{"diagonal", [3]float64{0.5, 0.5, 0.5}, [3]float64{0, 0, -1}, 1},
+		{"vertices", [3]float64{2, 2, 2}, [3]float64{-1, -1, -1}, 2},
+		{"edges", [3]float64{2, 2, 0.5}, [3]float64{-1, -1, 0}, 2},
+	}
+	for _, tt := range hits {
+		t.Run(tt.name, func(t *testing.T) {
+			var got int
+			b.raycast(tt.o, tt.d, func(uint32, float64) float64 {
+				got++
+				return math.Inf(1)
+			})
+			if got != tt.want {
+				t.Errorf("BVH.raycast() hits = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	starts := []struct {
+		name        string
+		origin, dir Point3D
+		want        Point3D
+	}{
+		{"fromVertex", Point3D{0, 0, 0}, Point3D{1, 1, 1}, Point3D{1, 1, 1}},
+		{"fromEdge", Point3D{0.5, 0, 0}, Point3D{0, 1, 1}, Point3D{0.5, 1, 1}},
+		{"fromDiagonal", Point3D{0.5, 0.5, 0}, Point3D{0, 0, 1}, Point3D{0.5, 0.5, 1}},
+	}
+	for _, tt := range starts {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.Raycast(tt.origin, tt.dir)
+			if !ok || !pointNear(got.Point, tt.want) || !near32(got.Distance, 1) {
+				t.Errorf("BVH.Raycast() = %v, want %v at 1", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBVH_Empty(t *testing.T) {
+	b := NewBVH(new(Mesh))
+	if _, ok := b.Raycast(Point3D{}, Point3D{1, 0, 0}); ok {
+		t.Error("BVH.Raycast() expected no hit")
+	}
+	if _, ok := b.ClosestPoint(Point3D{}); ok {
+		t.Error("BVH.ClosestPoint() expected no point")
+	}
+	if _, ok := b.SignedDistance(Point3D{}); ok {
+		t.Error("BVH.SignedDistance() expected no distance")
+	}
+	if b.Contains(Point3D{}) {
+		t.Error("BVH.Contains() expected false")
+	}
+}
@@ -0,0 +1,214 @@
+package go3mf
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/qmuntal/go3mf/spec"
+)
+
+// Clone returns a deep copy of m.
+//
+// Extension values stored in Any, AnyAttr and Resources.Assets are copied
+// when they implement spec.Cloner, else they are shared with m.
+// Mesh sources are always shared.
+//
+// Lazy attachments are read again from the package by the copy.
+// Other attachment streams are read into memory and replaced in m,
+// so both models can consume them independently.
+func (m *Model) Clone() *Model {
+	c := *m
+	c.Resources = m.Resources.clone()
+	c.Build = m.Build.clone()
+	if m.Attachments != nil {
+		c.Attachments = make([]Attachment, len(m.Attachments))
+		for i := range m.Attachments {
+			c.Attachments[i] = m.Attachments[i].clone()
+		}
+	}
+	c.Extensions = append([]Extension(nil), m.Extensions...)
+	c.Metadata = cloneMetadata(m.Metadata)
+	if m.Childs != nil {
+		c.Childs = make(map[string]*ChildModel, len(m.Childs))
+		for path, child := range m.Childs {
+			c.Childs[path] = child.Clone()
+		}
+	}
+	c.RootRelationships = append([]Relationship(nil), m.RootRelationships...)
+	c.Relationships = append([]Relationship(nil), m.Relationships...)
+	c.Any = m.Any.clone()
+	c.AnyAttr = m.AnyAttr.clone()
+	return &c
+}
+
+// Clone returns a deep copy of c.
+func (c *ChildModel) Clone() *ChildModel {
+	return &ChildModel{
+		Resources:     c.Resources.clone(),
+		Relationships: append([]Relationship(nil), c.Relationships...),
+		Any:           c.Any.clone(),
+	}
+}
+
+// Clone returns a deep copy of o.
+func (o *Object) Clone() *Object {
+	c := *o
+	c.Metadata = cloneMetadata(o.Metadata)
+	if o.Mesh != nil {
+		c.Mesh = o.Mesh.Clone()
+	}
+	if o.Components != nil {
+		c.Components = make([]*Component, len(o.Components))
+		for i, comp := range o.Components {
+			c.Components[i] = comp.Clone()
+		}
+	}
+	c.AnyAttr = o.AnyAttr.clone()
+	c.Any = o.Any.clone()
+	return &c
+}
+
+// Clone returns a deep copy of m.
+// The mesh source is shared.
+func (m *Mesh) Clone() *Mesh {
+	c := *m
+	c.Vertices = append([]Point3D(nil), m.Vertices...)
+	c.Triangles = append([]Triangle(nil), m.Triangles...)
+	if m.TriangleSets != nil {
+		c.TriangleSets = make([]TriangleSet, len(m.TriangleSets))
+		for i, ts := range m.TriangleSets {
+			c.TriangleSets[i] = ts
+			c.TriangleSets[i].Refs = append([]uint32(nil), ts.Refs...)
+		}
+	}
+	c.AnyAttr = m.AnyAttr.clone()
+	c.Any = m.Any.clone()
+	return &c
+}
+
+// Clone returns a deep copy of c.
+func (c *Component) Clone() *Component {
+	return &Component{ObjectID: c.ObjectID, Transform: c.Transform, AnyAttr: c.AnyAttr.clone()}
+}
+
+// Clone returns a deep copy of b.
+func (b *Item) Clone() *Item {
+	c := *b
+	c.Metadata = cloneMetadata(b.Metadata)
+	c.AnyAttr = b.AnyAttr.clone()
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *BaseMaterials) Clone() interface{} {
+	c := *r
+	c.Materials = append([]Base(nil), r.Materials...)
+	return &c
+}
+
+func (r *Resources) clone() Resources {
+	c := Resources{AnyAttr: r.AnyAttr.clone()}
+	if r.Assets != nil {
+		c.Assets = make([]Asset, len(r.Assets))
+		for i, a := range r.Assets {
+			c.Assets[i] = cloneAsset(a)
+		}
+	}
+	if r.Objects != nil {
+		c.Objects = make([]*Object, len(r.Objects))
+		for i, o := range r.Objects {
+			c.Objects[i] = o.Clone()
+		}
+	}
+	return c
+}
+
+func (b *Build) clone() Build {
+	c := Build{AnyAttr: b.AnyAttr.clone()}
+	if b.Items != nil {
+		c.Items = make([]*Item, len(b.Items))
+		for i, item := range b.Items {
+			c.Items[i] = item.Clone()
+		}
+	}
+	return c
+}
+
+func (a *Attachment) clone() Attachment {
+	c := *a
+	switch s := a.Stream.(type) {
+	case nil:
+	case *lazyReader:
+		c.Stream = &lazyReader{file: s.file}
+	case *bytes.Buffer:
+		c.Stream = bytes.NewBuffer(append([]byte(nil), s.Bytes()...))
+	default:
+		b, err := ioutil.ReadAll(s)
+		a.Stream = newBufferedStream(b, err)
+		c.Stream = newBufferedStream(b, err)
+	}
+	return c
+}
+
+// newBufferedStream returns a reader that reads b
+// and then returns err, if not nil.
+func newBufferedStream(b []byte, err error) io.Reader {
+	if err == nil {
+		return bytes.NewReader(b)
+	}
+	return io.MultiReader(bytes.NewReader(b), &errReader{err})
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func cloneAsset(a Asset) Asset {
+	if c, ok := a.(spec.Cloner); ok {
+		if ca, ok := c.Clone().(Asset); ok {
+			return ca
+		}
+	}
+	return a
+}
+
+func cloneMetadata(m []Metadata) []Metadata {
+	return append([]Metadata(nil), m...)
+}
+
+func (e AnyAttr) clone() AnyAttr {
+	if e == nil {
+		return nil
+	}
+	c := make(AnyAttr, len(e))
+	for i, a := range e {
+		c[i] = a
+		if cl, ok := a.(spec.Cloner); ok {
+			if ca, ok := cl.Clone().(spec.MarshalerAttr); ok {
+				c[i] = ca
+			}
+		}
+	}
+	return c
+}
+
+func (e Any) clone() Any {
+	if e == nil {
+		return nil
+	}
+	c := make(Any, len(e))
+	for i, a := range e {
+		c[i] = a
+		if cl, ok := a.(spec.Cloner); ok {
+			if ca, ok := cl.Clone().(spec.Marshaler); ok {
+				c[i] = ca
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,84 @@
+package go3mf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func (f *fakeAttr) Clone() interface{} {
+	c := *f
+	return &c
+}
+
+func TestModel_Clone(t *testing.T) {
+	asset := &fakeAsset{ID: 3}
+	m := &Model{
+		Path: "/3D/model.model", Language: "en-US", Units: UnitInch, Thumbnail: "/thumb.png",
+		AnyAttr:    AnyAttr{&fakeAttr{Value: "model"}},
+		Extensions: []Extension{{Namespace: fakeExtension, LocalName: "qm"}},
+		Metadata:   []Metadata{{Name: xml.Name{Local: "Title"}, Value: "Cube"}},
+		Resources: Resources{
+			Assets: []Asset{
+				&BaseMaterials{ID: 1, Materials: []Base{{Name: "Blue", Color: color.RGBA{0, 0, 255, 255}}}},
+				asset,
+			},
+			Objects: []*Object{
+				{ID: 2, AnyAttr: AnyAttr{&fakeAttr{Value: "object"}}, Mesh: &Mesh{
+					Vertices:     []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+					Triangles:    []Triangle{NewTriangle(0, 1, 2)},
+					TriangleSets: []TriangleSet{{Name: "set", Identifier: "id", Refs: []uint32{0}}},
+				}},
+				{ID: 4, Components: []*Component{{ObjectID: 2, Transform: Identity(), AnyAttr: AnyAttr{&fakeAttr{}}}}},
+			},
+		},
+		Build: Build{Items: []*Item{{ObjectID: 4, PartNumber: "1", Metadata: []Metadata{{Name: xml.Name{Local: "a"}}}}}},
+		Childs: map[string]*ChildModel{
+			"/other.model": {Resources: Resources{Objects: []*Object{{ID: 1}}}, Relationships: []Relationship{{Path: "/a.png"}}},
+		},
+		RootRelationships: []Relationship{{Path: "/thumb.png", Type: RelTypeThumbnail}},
+		Relationships:     []Relationship{{Path: "/3D/Texture/a.png"}},
+		Attachments: []Attachment{
+			{Path: "/thumb.png", ContentType: "image/png", Stream: bytes.NewBufferString("thumb")},
+			{Path: "/3D/Texture/a.png", ContentType: "image/png", Stream: strings.NewReader("texture")},
+			{Path: "/3D/Texture/b.png", ContentType: "image/png", Stream: &lazyReader{file: &fakePackageFile{data: []byte("lazy")}}},
+		},
+	}
+	got := m.Clone()
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatalf("Model.Clone() = %v", diff)
+	}
+	if got.Resources.Assets[1] != asset {
+		t.Error("Model.Clone() should share assets that do not implement spec.Cloner")
+	}
+	got.AnyAttr[0].(*fakeAttr).Value = "other"
+	got.Resources.Assets[0].(*BaseMaterials).Materials[0].Name = "Red"
+	got.Resources.Objects[0].Mesh.Vertices[0] = Point3D{5, 5, 5}
+	got.Resources.Objects[0].Mesh.TriangleSets[0].Refs[0] = 2
+	got.Resources.Objects[1].Components[0].ObjectID = 3
+	got.Build.Items[0].Metadata[0].Value = "b"
+	got.Childs["/other.model"].Resources.Objects[0].ID = 2
+	got.Relationships[0].Path = "/other.png"
+	if m.AnyAttr[0].(*fakeAttr).Value != "model" ||
+		m.Resources.Assets[0].(*BaseMaterials).Materials[0].Name != "Blue" ||
+		m.Resources.Objects[0].Mesh.Vertices[0] != (Point3D{0, 0, 0}) ||
+		m.Resources.Objects[0].Mesh.TriangleSets[0].Refs[0] != 0 ||
+		m.Resources.Objects[1].Components[0].ObjectID != 2 ||
+		m.Build.Items[0].Metadata[0].Value != "" ||
+		m.Childs["/other.model"].Resources.Objects[0].ID != 1 ||
+		m.Relationships[0].Path != "/3D/Texture/a.png" {
+		t.Error("Model.Clone() modifying the clone modified the original model")
+	}
+	for i := range m.Attachments {
+		want, _ := ioutil.ReadAll(m.Attachments[i].Stream)
+		data, _ := ioutil.ReadAll(got.Attachments[i].Stream)
+		if string(data) != string(want) || len(data) == 0 {
+			t.Errorf("Model.Clone() attachment %d = %s, want %s", i, data, want)
+		}
+	}
+}
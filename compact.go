@@ -0,0 +1,338 @@
+package go3mf
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/qmuntal/go3mf/spec"
+)
+
+// Compact removes the assets and objects that are not reachable from a build item,
+// directly or through other resources, and the child models left empty.
+// It then removes the attachments that nothing references and renumbers
+// the resource IDs of each model part densely, starting from 1 and preserving their order.
+//
+// Extension values take part through spec.IDRemapper, spec.PartIDRemapper and spec.PathRemapper,
+// which are also used to find the resources and parts they reference.
+// Assets that do not implement spec.IDRemapper are always kept with their ID.
+// Attachments only referenced by model relationships that are not
+// a must preserve, print ticket or thumbnail are removed together with the relationships.
+func (m *Model) Compact() {
+	c := compacter{m: m, reached: make(map[resourceKey]struct{})}
+	c.reach()
+	c.removeResources()
+	c.removeAttachments()
+	c.renumber()
+}
+
+type resourceKey struct {
+	path string // empty for the root model.
+	id   uint32
+}
+
+type compacter struct {
+	m       *Model
+	reached map[resourceKey]struct{}
+	pending []resourceKey
+	paths   map[string]struct{} // lower case referenced paths.
+}
+
+// partPath returns the normalized path of the model part,
+// which is empty for the root model.
+func (c *compacter) partPath(p string) string {
+	if p == c.m.PathOrDefault() {
+		return ""
+	}
+	return p
+}
+
+func (c *compacter) mark(path string, id uint32) {
+	if id == 0 {
+		return
+	}
+	k := resourceKey{c.partPath(path), id}
+	if _, ok := c.reached[k]; !ok {
+		c.reached[k] = struct{}{}
+		c.pending = append(c.pending, k)
+	}
+}
+
+func (c *compacter) reach() {
+	for _, item := range c.m.Build.Items {
+		c.mark(item.ObjectPath(), item.ObjectID)
+	}
+	for len(c.pending) > 0 {
+		k := c.pending[len(c.pending)-1]
+		c.pending = c.pending[:len(c.pending)-1]
+		rs, ok := c.m.FindResources(k.path)
+		if !ok {
+			continue
+		}
+		if o, ok := rs.FindObject(k.id); ok {
+			c.reachObject(k.path, o)
+		}
+		if a, ok := rs.FindAsset(k.id); ok {
+			c.reachExtension(k.path, a)
+		}
+	}
+}
+
+func (c *compacter) reachObject(path string, o *Object) {
+	c.mark(path, o.PID)
+	for _, a := range o.AnyAttr {
+		c.reachExtension(path, a)
+	}
+	for _, a := range o.Any {
+		c.reachExtension(path, a)
+	}
+	if o.Mesh != nil {
+		for _, t := range o.Mesh.Triangles {
+			c.mark(path, t.PID())
+		}
+		for _, a := range o.Mesh.AnyAttr {
+			c.reachExtension(path, a)
+		}
+		for _, a := range o.Mesh.Any {
+			c.reachExtension(path, a)
+		}
+	}
+	for _, comp := range o.Components {
+		c.mark(c.objectPath(path, comp.ObjectPath("")), comp.ObjectID)
+	}
+}
+
+func (c *compacter) objectPath(part, objectPath string) string {
+	if objectPath == "" {
+		return part
+	}
+	return objectPath
+}
+
+// reachExtension marks the resources referenced by v.
+// A reference to another model part marks all of its resources.
+func (c *compacter) reachExtension(path string, v interface{}) {
+	if r, ok := v.(spec.IDRemapper); ok {
+		r.RemapIDs(func(id uint32) uint32 {
+			c.mark(path, id)
+			return id
+		})
+	}
+	if r, ok := v.(spec.PartIDRemapper); ok {
+		r.RemapPartIDs(func(p string, id uint32) uint32 {
+			c.mark(c.objectPath(path, p), id)
+			return id
+		})
+	}
+	if r, ok := v.(spec.PathRemapper); ok {
+		r.RemapPaths(func(p string) string {
+			if child, ok := c.m.Childs[p]; ok {
+				for _, a := range child.Resources.Assets {
+					c.mark(p, a.Identify())
+				}
+				for _, o := range child.Resources.Objects {
+					c.mark(p, o.ID)
+				}
+			}
+			return p
+		})
+	}
+}
+
+func (c *compacter) isReached(path string, id uint32) bool {
+	_, ok := c.reached[resourceKey{path, id}]
+	return ok
+}
+
+func (c *compacter) removeResources() {
+	c.compactResources("", &c.m.Resources)
+	for path, child := range c.m.Childs {
+		c.compactResources(path, &child.Resources)
+		if len(child.Resources.Assets) == 0 && len(child.Resources.Objects) == 0 {
+			delete(c.m.Childs, path)
+		}
+	}
+}
+
+func (c *compacter) compactResources(path string, rs *Resources) {
+	assets := rs.Assets[:0]
+	for _, a := range rs.Assets {
+		if _, ok := a.(spec.IDRemapper); !ok || c.isReached(path, a.Identify()) {
+			assets = append(assets, a)
+		}
+	}
+	for i := len(assets); i < len(rs.Assets); i++ {
+		rs.Assets[i] = nil
+	}
+	rs.Assets = assets
+	objs := rs.Objects[:0]
+	for _, o := range rs.Objects {
+		if c.isReached(path, o.ID) {
+			objs = append(objs, o)
+		}
+	}
+	for i := len(objs); i < len(rs.Objects); i++ {
+		rs.Objects[i] = nil
+	}
+	rs.Objects = objs
+}
+
+func (c *compacter) addPath(p string) {
+	if p != "" {
+		c.paths[strings.ToLower(p)] = struct{}{}
+	}
+}
+
+func (c *compacter) isPathReferenced(p string) bool {
+	_, ok := c.paths[strings.ToLower(p)]
+	return ok
+}
+
+func (c *compacter) removeAttachments() {
+	c.paths = make(map[string]struct{})
+	c.addPath(c.m.Thumbnail)
+	for _, r := range c.m.RootRelationships {
+		c.addPath(resolveRelationship("/", r.Path))
+	}
+	c.addRelationshipPaths(c.m.PathOrDefault(), c.m.Relationships)
+	c.addResourcesPaths(&c.m.Resources)
+	for path, child := range c.m.Childs {
+		c.addRelationshipPaths(path, child.Relationships)
+		c.addResourcesPaths(&child.Resources)
+	}
+	atts := c.m.Attachments[:0]
+	removed := make(map[string]struct{})
+	for _, a := range c.m.Attachments {
+		if c.isPathReferenced(a.Path) {
+			atts = append(atts, a)
+		} else {
+			removed[strings.ToLower(a.Path)] = struct{}{}
+		}
+	}
+	for i := len(atts); i < len(c.m.Attachments); i++ {
+		c.m.Attachments[i] = Attachment{}
+	}
+	c.m.Attachments = atts
+	c.m.Relationships = removeRelationships(c.m.PathOrDefault(), c.m.Relationships, removed)
+	for path, child := range c.m.Childs {
+		child.Relationships = removeRelationships(path, child.Relationships, removed)
+	}
+}
+
+func (c *compacter) addRelationshipPaths(source string, rels []Relationship) {
+	for _, r := range rels {
+		if r.Type == RelTypeMustPreserve || r.Type == RelTypePrintTicket || r.Type == RelTypeThumbnail {
+			c.addPath(resolveRelationship(source, r.Path))
+		}
+	}
+}
+
+func (c *compacter) addResourcesPaths(rs *Resources) {
+	addPaths := func(v interface{}) {
+		if r, ok := v.(spec.PathRemapper); ok {
+			r.RemapPaths(func(p string) string {
+				c.addPath(p)
+				return p
+			})
+		}
+	}
+	for _, a := range rs.Assets {
+		addPaths(a)
+	}
+	for _, o := range rs.Objects {
+		c.addPath(o.Thumbnail)
+		for _, a := range o.AnyAttr {
+			addPaths(a)
+		}
+		for _, a := range o.Any {
+			addPaths(a)
+		}
+		if o.Mesh != nil {
+			for _, a := range o.Mesh.AnyAttr {
+				addPaths(a)
+			}
+			for _, a := range o.Mesh.Any {
+				addPaths(a)
+			}
+		}
+	}
+}
+
+func removeRelationships(source string, rels []Relationship, removed map[string]struct{}) []Relationship {
+	if len(removed) == 0 {
+		return rels
+	}
+	kept := rels[:0]
+	for _, r := range rels {
+		if _, ok := removed[strings.ToLower(resolveRelationship(source, r.Path))]; !ok {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+func (c *compacter) renumber() {
+	ids := map[string]idMapper{"": newDenseIDMapper(&c.m.Resources)}
+	for path, child := range c.m.Childs {
+		ids[path] = newDenseIDMapper(&child.Resources)
+	}
+	remapFor := func(path string) func(uint32) uint32 {
+		if m, ok := ids[c.partPath(path)]; ok {
+			return m.remap
+		}
+		return idMapper(nil).noop
+	}
+	noPaths := func(p string) string { return p }
+	renumberResources := func(path string, rs *Resources) {
+		remap := remapFor(path)
+		partIDs := func(p string, id uint32) uint32 {
+			return remapFor(c.objectPath(path, p))(id)
+		}
+		for _, a := range rs.Assets {
+			remapExtension(a, remap, noPaths, partIDs)
+		}
+		for _, o := range rs.Objects {
+			o.remap(remap, noPaths, partIDs)
+		}
+	}
+	renumberResources("", &c.m.Resources)
+	for path, child := range c.m.Childs {
+		renumberResources(path, &child.Resources)
+	}
+	for _, item := range c.m.Build.Items {
+		item.ObjectID = remapFor(item.ObjectPath())(item.ObjectID)
+	}
+}
+
+// newDenseIDMapper maps the resource IDs to consecutive IDs starting from 1,
+// skipping the IDs of the assets that cannot be renumbered.
+func newDenseIDMapper(rs *Resources) idMapper {
+	reserved := make(map[uint32]struct{})
+	var ids []uint32
+	for _, a := range rs.Assets {
+		if _, ok := a.(spec.IDRemapper); ok {
+			ids = append(ids, a.Identify())
+		} else {
+			reserved[a.Identify()] = struct{}{}
+		}
+	}
+	for _, o := range rs.Objects {
+		ids = append(ids, o.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	m := make(idMapper, len(ids))
+	next := uint32(1)
+	for _, id := range ids {
+		for {
+			if _, ok := reserved[next]; !ok {
+				break
+			}
+			next++
+		}
+		m[id] = next
+		next++
+	}
+	return m
+}
@@ -0,0 +1,108 @@
+package go3mf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+type fakeTexture struct {
+	ID   uint32
+	Path string
+}
+
+func (f *fakeTexture) Identify() uint32 { return f.ID }
+
+func (f *fakeTexture) RemapIDs(fn func(uint32) uint32) { f.ID = fn(f.ID) }
+
+func (f *fakeTexture) RemapPaths(fn func(string) string) { f.Path = fn(f.Path) }
+
+type fakeGroup struct {
+	ID        uint32
+	TextureID uint32
+}
+
+func (f *fakeGroup) Identify() uint32 { return f.ID }
+
+func (f *fakeGroup) RemapIDs(fn func(uint32) uint32) {
+	f.ID = fn(f.ID)
+	f.TextureID = fn(f.TextureID)
+}
+
+func TestModel_Compact(t *testing.T) {
+	m := &Model{
+		Thumbnail: "/thumb.png",
+		Resources: Resources{
+			Assets: []Asset{
+				&BaseMaterials{ID: 1},
+				&fakeTexture{ID: 3, Path: "/3D/Textures/used.png"},
+				&fakeTexture{ID: 4, Path: "/3D/Textures/orphan.png"},
+				&fakeGroup{ID: 6, TextureID: 3},
+				&fakeAsset{ID: 7},
+			},
+			Objects: []*Object{
+				{ID: 5, PID: 6, Mesh: &Mesh{Triangles: []Triangle{NewTrianglePID(0, 1, 2, 6, 0, 0, 0)}}},
+				{ID: 8, Mesh: &Mesh{}},
+				{ID: 10, Components: []*Component{
+					{ObjectID: 5},
+					{ObjectID: 2, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/used.model"}}},
+				}},
+			},
+		},
+		Build: Build{Items: []*Item{{ObjectID: 10}, {ObjectID: 3, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/used.model"}}}}},
+		Childs: map[string]*ChildModel{
+			"/3D/used.model": {Resources: Resources{Objects: []*Object{
+				{ID: 1, Mesh: &Mesh{}}, {ID: 2, Mesh: &Mesh{}}, {ID: 3, Components: []*Component{{ObjectID: 2}}},
+			}}},
+			"/3D/orphan.model": {Resources: Resources{Objects: []*Object{{ID: 1, Mesh: &Mesh{}}}}},
+		},
+		Attachments: []Attachment{
+			{Path: "/thumb.png", Stream: bytes.NewBufferString("thumb")},
+			{Path: "/3D/Textures/used.png", Stream: bytes.NewBufferString("used")},
+			{Path: "/3D/Textures/orphan.png", Stream: bytes.NewBufferString("orphan")},
+			{Path: "/3D/Metadata/pt.xml", Stream: bytes.NewBufferString("pt")},
+		},
+		Relationships: []Relationship{
+			{Path: "/3D/Textures/used.png", Type: "texture"},
+			{Path: "/3D/Textures/orphan.png", Type: "texture"},
+			{Path: "Metadata/pt.xml", Type: RelTypePrintTicket},
+		},
+	}
+	want := &Model{
+		Thumbnail: "/thumb.png",
+		Resources: Resources{
+			Assets: []Asset{
+				&fakeTexture{ID: 1, Path: "/3D/Textures/used.png"},
+				&fakeGroup{ID: 3, TextureID: 1},
+				&fakeAsset{ID: 7},
+			},
+			Objects: []*Object{
+				{ID: 2, PID: 3, Mesh: &Mesh{Triangles: []Triangle{NewTrianglePID(0, 1, 2, 3, 0, 0, 0)}}},
+				{ID: 4, Components: []*Component{
+					{ObjectID: 2},
+					{ObjectID: 1, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/used.model"}}},
+				}},
+			},
+		},
+		Build: Build{Items: []*Item{{ObjectID: 4}, {ObjectID: 2, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/used.model"}}}}},
+		Childs: map[string]*ChildModel{
+			"/3D/used.model": {Resources: Resources{Objects: []*Object{
+				{ID: 1, Mesh: &Mesh{}}, {ID: 2, Components: []*Component{{ObjectID: 1}}},
+			}}},
+		},
+		Attachments: []Attachment{
+			{Path: "/thumb.png", Stream: bytes.NewBufferString("thumb")},
+			{Path: "/3D/Textures/used.png", Stream: bytes.NewBufferString("used")},
+			{Path: "/3D/Metadata/pt.xml", Stream: bytes.NewBufferString("pt")},
+		},
+		Relationships: []Relationship{
+			{Path: "/3D/Textures/used.png", Type: "texture"},
+			{Path: "Metadata/pt.xml", Type: RelTypePrintTicket},
+		},
+	}
+	m.Compact()
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Model.Compact() = %v", diff)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"image/color"
 	"io"
+	"io/ioutil"
 	"sort"
 	"sync"
 
@@ -13,6 +14,8 @@ import (
 const (
 	// Namespace is the canonical name of this extension.
 	Namespace = "http://schemas.microsoft.com/3dmanufacturing/core/2015/02"
+	// NamespaceTriangleSets is the canonical name of the core triangle sets namespace.
+	NamespaceTriangleSets = "http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07"
 
 	// RelType3DModel is the canonical 3D model relationship type.
 	RelType3DModel = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"
@@ -22,11 +25,17 @@ const (
 	RelTypePrintTicket = "http://schemas.microsoft.com/3dmanufacturing/2013/01/printticket"
 	// RelTypeMustPreserve is the canonical must preserve relationship type.
 	RelTypeMustPreserve = "http://schemas.openxmlformats.org/package/2006/relationships/mustpreserve"
+	// RelTypeKeyStore is the canonical key store relationship type.
+	RelTypeKeyStore = "http://schemas.microsoft.com/3dmanufacturing/2019/07/keystore"
+	// RelTypeEncryptedFile is the canonical encrypted file relationship type.
+	RelTypeEncryptedFile = "http://schemas.microsoft.com/3dmanufacturing/2019/07/encryptedfile"
 
 	// DefaultModelPath is the recommended root model part name.
 	DefaultModelPath = "/3D/3dmodel.model"
 	// DefaultPrintTicketName is the recommended print ticket part name.
 	DefaultPrintTicketName = "/3D/Metadata/Model_PT.xml"
+	// DefaultKeyStorePath is the recommended key store part name.
+	DefaultKeyStorePath = "/3D/Keystore.model"
 	// Default3DTexturesDir is the recommended directory for 3D textures.
 	Default3DTexturesDir = "/3D/Textures/"
 	// Default3DOtherDir is the recommended directory for non-standard parts.
@@ -38,6 +47,8 @@ const (
 	ContentType3DModel = "application/vnd.ms-package.3dmanufacturing-3dmodel+xml"
 	// ContentTypePrintTicket is the print ticket content type.
 	ContentTypePrintTicket = "application/vnd.ms-printing.printticket+xml"
+	// ContentTypeKeyStore is the key store content type.
+	ContentTypeKeyStore = "application/vnd.ms-package.3dmanufacturing-keystore+xml"
 )
 
 // Units define the allowed model units.
@@ -101,12 +112,26 @@ type Metadata struct {
 }
 
 // Attachment defines the Model Attachment.
+//
+// Attachments read by a Decoder are lazy by default,
+// meaning that Stream reads from the package only when consumed.
 type Attachment struct {
 	Stream      io.Reader
 	Path        string
 	ContentType string
 }
 
+// Open returns a reader for the attachment content.
+// Lazy attachments are read again from the package each time Open is called,
+// so the package must not be closed yet. Otherwise Stream is returned
+// and closing the reader does not close Stream, which is owned by the caller.
+func (a *Attachment) Open() (io.ReadCloser, error) {
+	if lr, ok := a.Stream.(*lazyReader); ok {
+		return lr.file.Open()
+	}
+	return ioutil.NopCloser(a.Stream), nil
+}
+
 // Relationship defines a dependency between
 // the owner of the relationsip and the attachment
 // referenced by path. ID is optional, if not set a random
@@ -338,8 +363,9 @@ type Base struct {
 
 // BaseMaterials defines a slice of Base.
 type BaseMaterials struct {
-	ID        uint32
-	Materials []Base
+	ID                  uint32
+	DisplayPropertiesID uint32
+	Materials           []Base
 }
 
 // Len returns the materials count.
@@ -394,6 +420,7 @@ type Object struct {
 	Mesh       *Mesh
 	Components []*Component
 	AnyAttr    AnyAttr
+	Any        Any
 }
 
 func (o *Object) boundingBox(m *Model, path string) Box {
@@ -491,11 +518,37 @@ func (t Triangle) PIndices() (uint32, uint32, uint32) {
 // Each node and face have an ID, which allows to identify them. Each face have an
 // orientation (i.e. the face can look up or look down) and have three nodes.
 // The orientation is defined by the order of its nodes.
+//
+// If Source is not nil the encoder pulls the vertices and triangles from it
+// instead of using Vertices and Triangles. The rest of the package,
+// including validation, triangle sets, flattening, mass properties and
+// topology analysis, only looks at Vertices and Triangles, so it treats
+// a mesh that is only backed by Source as an empty mesh.
 type Mesh struct {
-	Vertices  []Point3D
-	Triangles []Triangle
-	AnyAttr   AnyAttr
-	Any       Any
+	Vertices     []Point3D
+	Triangles    []Triangle
+	TriangleSets []TriangleSet
+	Source       MeshSource
+	AnyAttr      AnyAttr
+	Any          Any
+}
+
+// MeshSource supplies the vertices and triangles of a mesh on demand,
+// so meshes can be encoded without holding them in memory.
+//
+// NextVertex and NextTriangle return io.EOF when there are no more elements.
+// The encoder first pulls all the vertices and then all the triangles, only once.
+type MeshSource interface {
+	NextVertex() (Point3D, error)
+	NextTriangle() (Triangle, error)
+}
+
+// TriangleSet groups a subset of the mesh triangles under a name.
+// Refs contains the indices of the triangles in the set.
+type TriangleSet struct {
+	Name       string
+	Identifier string
+	Refs       []uint32
 }
 
 // BoundingBox returns the bounding box of the mesh.
@@ -598,6 +651,7 @@ const (
 	attrBaseMaterials = "basematerials"
 	attrBase          = "base"
 	attrDisplayColor  = "displaycolor"
+	attrDisplayPropID = "displaypropertiesid"
 	attrPartNumber    = "partnumber"
 	attrItem          = "item"
 	attrModel         = "model"
@@ -625,4 +679,12 @@ const (
 	attrMetadata      = "metadata"
 	attrMetadataGroup = "metadatagroup"
 	attrPath          = "path"
+	attrTriangleSets  = "trianglesets"
+	attrTriangleSet   = "triangleset"
+	attrIdentifier    = "identifier"
+	attrRef           = "ref"
+	attrRefRange      = "refrange"
+	attrIndex         = "index"
+	attrStartIndex    = "startindex"
+	attrEndIndex      = "endindex"
 )
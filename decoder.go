@@ -14,9 +14,10 @@ import (
 
 type modelDecoder struct {
 	baseDecoder
-	model  *Model
-	isRoot bool
-	path   string
+	model    *Model
+	isRoot   bool
+	path     string
+	onObject func(*Object)
 }
 
 func (d *modelDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
@@ -24,7 +25,7 @@ func (d *modelDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 		switch name.Local {
 		case attrResources:
 			resources, _ := d.model.FindResources(d.path)
-			child = &resourceDecoder{resources: resources, model: d.model}
+			child = &resourceDecoder{resources: resources, model: d.model, onObject: d.onObject}
 		case attrBuild:
 			if d.isRoot {
 				child = &buildDecoder{build: &d.model.Build, model: d.model}
@@ -249,6 +250,8 @@ type resourceDecoder struct {
 	baseDecoder
 	model     *Model
 	resources *Resources
+	onObject  func(*Object)
+	streamed  int
 }
 
 func (d *resourceDecoder) Wrap(err error) error {
@@ -259,7 +262,10 @@ func (d *resourceDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 	if name.Space == Namespace {
 		switch name.Local {
 		case attrObject:
-			child = &objectDecoder{resources: d.resources, model: d.model}
+			child = &objectDecoder{resources: d.resources, model: d.model, onEnd: d.onObject, index: len(d.resources.Objects) + d.streamed}
+			if d.onObject != nil {
+				d.streamed++
+			}
 		case attrBaseMaterials:
 			child = &baseMaterialsDecoder{resources: d.resources}
 		}
@@ -295,13 +301,22 @@ func (d *baseMaterialsDecoder) Start(attrs []spec.Attr) error {
 	var errs error
 	d.baseMaterialDecoder.resource = &d.resource
 	for _, a := range attrs {
-		if a.Name.Space == "" && a.Name.Local == attrID {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
 			id, err := strconv.ParseUint(string(a.Value), 10, 32)
 			if err != nil {
 				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
 			}
 			d.resource.ID = uint32(id)
-			break
+		case attrDisplayPropID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DisplayPropertiesID = uint32(id)
 		}
 	}
 	if errs != nil {
@@ -361,12 +376,135 @@ func (d *meshDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 		} else if name.Local == attrTriangles {
 			child = &trianglesDecoder{resource: d.resource}
 		}
+	} else if name.Space == NamespaceTriangleSets {
+		if name.Local == attrTriangleSets {
+			child = &triangleSetsDecoder{mesh: d.resource.Mesh}
+		}
 	} else if ext, ok := loadExtension(name.Space); ok {
 		child = ext.CreateElementDecoder(d.resource.Mesh, name.Local)
 	}
 	return
 }
 
+type triangleSetsDecoder struct {
+	baseDecoder
+	mesh *Mesh
+}
+
+func (d *triangleSetsDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == NamespaceTriangleSets && name.Local == attrTriangleSet {
+		child = &triangleSetDecoder{mesh: d.mesh}
+	}
+	return
+}
+
+type triangleSetDecoder struct {
+	baseDecoder
+	mesh        *Mesh
+	triangleSet TriangleSet
+	refDecoder  triangleRefDecoder
+}
+
+func (d *triangleSetDecoder) End() {
+	d.mesh.TriangleSets = append(d.mesh.TriangleSets, d.triangleSet)
+}
+
+func (d *triangleSetDecoder) Start(attrs []spec.Attr) error {
+	d.refDecoder.mesh = d.mesh
+	d.refDecoder.triangleSet = &d.triangleSet
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			d.triangleSet.Name = string(a.Value)
+		case attrIdentifier:
+			d.triangleSet.Identifier = string(a.Value)
+		}
+	}
+	return nil
+}
+
+func (d *triangleSetDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.triangleSet, len(d.mesh.TriangleSets))
+}
+
+func (d *triangleSetDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == NamespaceTriangleSets {
+		if name.Local == attrRef {
+			d.refDecoder.isRange = false
+			child = &d.refDecoder
+		} else if name.Local == attrRefRange {
+			d.refDecoder.isRange = true
+			child = &d.refDecoder
+		}
+	}
+	return
+}
+
+// triangleRef and triangleRefRange identify
+// the triangle set references in decoding errors.
+type triangleRef struct{ Index uint32 }
+type triangleRefRange struct{ Start, End uint32 }
+
+type triangleRefDecoder struct {
+	baseDecoder
+	mesh        *Mesh
+	triangleSet *TriangleSet
+	isRange     bool
+	count       int
+}
+
+func (d *triangleRefDecoder) Start(attrs []spec.Attr) error {
+	var (
+		index, start, end uint64
+		errs              error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var field *uint64
+		switch a.Name.Local {
+		case attrIndex:
+			field = &index
+		case attrStartIndex:
+			field = &start
+		case attrEndIndex:
+			field = &end
+		default:
+			continue
+		}
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+		}
+		*field = val
+	}
+	idx := d.count
+	d.count++
+	if !d.isRange {
+		d.triangleSet.Refs = append(d.triangleSet.Refs, uint32(index))
+		return specerr.WrapIndex(errs, triangleRef{uint32(index)}, idx)
+	}
+	ref := triangleRefRange{uint32(start), uint32(end)}
+	if end < start {
+		errs = specerr.Append(errs, specerr.NewParseAttrError(attrEndIndex, true))
+		return specerr.WrapIndex(errs, ref, idx)
+	}
+	// Ranges are only expanded up to the decoded triangles,
+	// so a huge range cannot exhaust the memory.
+	if end >= uint64(len(d.mesh.Triangles)) {
+		errs = specerr.Append(errs, specerr.ErrIndexOutOfBounds)
+		return specerr.WrapIndex(errs, ref, idx)
+	}
+	for i := start; i <= end; i++ {
+		d.triangleSet.Refs = append(d.triangleSet.Refs, uint32(i))
+	}
+	return specerr.WrapIndex(errs, ref, idx)
+}
+
 type verticesDecoder struct {
 	baseDecoder
 	mesh          *Mesh
@@ -511,9 +649,15 @@ type objectDecoder struct {
 	model     *Model
 	resources *Resources
 	resource  Object
+	index     int
+	onEnd     func(*Object)
 }
 
 func (d *objectDecoder) End() {
+	if d.onEnd != nil {
+		d.onEnd(&d.resource)
+		return
+	}
 	d.resources.Objects = append(d.resources.Objects, &d.resource)
 }
 
@@ -527,13 +671,13 @@ func (d *objectDecoder) Start(attrs []spec.Attr) error {
 		}
 	}
 	if errs != nil {
-		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Objects))
+		return specerr.WrapIndex(errs, &d.resource, d.index)
 	}
 	return errs
 }
 
 func (d *objectDecoder) Wrap(err error) error {
-	return specerr.WrapIndex(err, &d.resource, len(d.resources.Objects))
+	return specerr.WrapIndex(err, &d.resource, d.index)
 }
 
 func (d *objectDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
@@ -545,6 +689,8 @@ func (d *objectDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 		} else if name.Local == attrMetadataGroup {
 			child = &metadataGroupDecoder{metadatas: &d.resource.Metadata, model: d.model}
 		}
+	} else if ext, ok := loadExtension(name.Space); ok {
+		child = ext.CreateElementDecoder(&d.resource, name.Local)
 	}
 	return
 }
@@ -648,15 +794,16 @@ func (d *baseDecoder) End()                    {}
 
 type topLevelDecoder struct {
 	baseDecoder
-	model  *Model
-	isRoot bool
-	path   string
+	model    *Model
+	isRoot   bool
+	path     string
+	onObject func(*Object)
 }
 
 func (d *topLevelDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 	modelName := xml.Name{Space: Namespace, Local: attrModel}
 	if name == modelName {
-		child = &modelDecoder{model: d.model, isRoot: d.isRoot, path: d.path}
+		child = &modelDecoder{model: d.model, isRoot: d.isRoot, path: d.path, onObject: d.onObject}
 	}
 	return
 }
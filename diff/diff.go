@@ -0,0 +1,454 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+// Kind defines the type of a change.
+type Kind uint8
+
+// Supported change kinds.
+const (
+	KindChanged Kind = iota
+	KindAdded
+	KindRemoved
+)
+
+func (k Kind) String() string {
+	return map[Kind]string{
+		KindChanged: "changed",
+		KindAdded:   "added",
+		KindRemoved: "removed",
+	}[k]
+}
+
+// Change defines a difference between two models.
+//
+// Path is the path of the child model that contains the change,
+// empty for the root model. Target are the levels of the changed element,
+// from the outermost to the innermost one. Resources and objects are matched by ID,
+// which is used as the level index, and build items and components by position.
+// Metadata with the same name are matched in order of appearance.
+// Only the first differing vertex and triangle of a mesh are reported.
+// Field is the name of the changed field or empty if the whole element changed.
+type Change struct {
+	Kind   Kind
+	Path   string
+	Target []errors.Level
+	Field  string
+	From   interface{}
+	To     interface{}
+}
+
+func (c *Change) String() string {
+	levels := make([]string, 0, len(c.Target)+2)
+	if c.Path != "" {
+		levels = append(levels, c.Path)
+	}
+	for i := range c.Target {
+		levels = append(levels, c.Target[i].String())
+	}
+	s := c.Kind.String()
+	if c.Field != "" {
+		s = c.Field + " " + s
+	}
+	if len(levels) == 0 {
+		return s
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(levels, "@"), s)
+}
+
+// Options defines the comparison options.
+//
+// Tolerance is the maximum absolute difference between two floats
+// of vertices and transforms to be considered equal.
+//
+// If AttachmentContent is true the attachment streams are also compared.
+// The streams are read into memory and replaced by a bytes.Reader
+// with the same content, so the models can still be encoded afterwards.
+type Options struct {
+	Tolerance         float32
+	AttachmentContent bool
+}
+
+// Compare returns the changes needed to transform from into to.
+// Extension values are compared with reflect.DeepEqual.
+func Compare(from, to *go3mf.Model, opts Options) []Change {
+	d := differ{opts: opts}
+	d.compareModel(from, to)
+	return d.changes
+}
+
+type differ struct {
+	opts    Options
+	path    string
+	changes []Change
+}
+
+func (d *differ) add(kind Kind, target []errors.Level, field string, from, to interface{}) {
+	d.changes = append(d.changes, Change{
+		Kind: kind, Path: d.path, Target: append([]errors.Level(nil), target...), Field: field, From: from, To: to,
+	})
+}
+
+func (d *differ) field(target []errors.Level, field string, from, to interface{}) {
+	if !equal(from, to) {
+		d.add(KindChanged, target, field, from, to)
+	}
+}
+
+// equal is like reflect.DeepEqual but nil and empty slices are equal.
+func equal(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == reflect.Slice && vb.Kind() == reflect.Slice && va.Len() == 0 && vb.Len() == 0 {
+		return va.Type() == vb.Type()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func level(element interface{}, index int) errors.Level {
+	return errors.Level{Element: element, Index: index}
+}
+
+func (d *differ) compareModel(from, to *go3mf.Model) {
+	d.field(nil, "Path", from.PathOrDefault(), to.PathOrDefault())
+	d.field(nil, "Language", from.Language, to.Language)
+	d.field(nil, "Units", from.Units, to.Units)
+	d.field(nil, "Thumbnail", from.Thumbnail, to.Thumbnail)
+	d.field(nil, "Extensions", from.Extensions, to.Extensions)
+	d.field(nil, "Any", from.Any, to.Any)
+	d.field(nil, "AnyAttr", from.AnyAttr, to.AnyAttr)
+	d.compareMetadata(nil, from.Metadata, to.Metadata)
+	d.compareResources(&from.Resources, &to.Resources)
+	d.compareBuild(&from.Build, &to.Build)
+	d.compareRelationships(nil, "RootRelationships", from.RootRelationships, to.RootRelationships)
+	d.compareRelationships(nil, "Relationships", from.Relationships, to.Relationships)
+	d.compareAttachments(from.Attachments, to.Attachments)
+	d.compareChilds(from.Childs, to.Childs)
+}
+
+func (d *differ) compareChilds(from, to map[string]*go3mf.ChildModel) {
+	for _, path := range sortedKeys(from, to) {
+		fc, inFrom := from[path]
+		tc, inTo := to[path]
+		d.path = path
+		switch {
+		case !inTo:
+			d.add(KindRemoved, nil, "", fc, nil)
+		case !inFrom:
+			d.add(KindAdded, nil, "", nil, tc)
+		default:
+			d.field(nil, "Any", fc.Any, tc.Any)
+			d.compareResources(&fc.Resources, &tc.Resources)
+			d.compareRelationships(nil, "Relationships", fc.Relationships, tc.Relationships)
+		}
+	}
+	d.path = ""
+}
+
+func (d *differ) compareMetadata(target []errors.Level, from, to []go3mf.Metadata) {
+	matched := make([]bool, len(to))
+	find := func(name string) (int, bool) {
+		for j := range to {
+			if !matched[j] && metadataName(&to[j]) == name {
+				matched[j] = true
+				return j, true
+			}
+		}
+		return 0, false
+	}
+	for i := range from {
+		j, ok := find(metadataName(&from[i]))
+		lvl := append(target, level(from[i], i))
+		if !ok {
+			d.add(KindRemoved, lvl, "", from[i], nil)
+			continue
+		}
+		d.field(lvl, "Value", from[i].Value, to[j].Value)
+		d.field(lvl, "Type", from[i].Type, to[j].Type)
+		d.field(lvl, "Preserve", from[i].Preserve, to[j].Preserve)
+	}
+	for j := range to {
+		if !matched[j] {
+			d.add(KindAdded, append(target, level(to[j], j)), "", nil, to[j])
+		}
+	}
+}
+
+func metadataName(m *go3mf.Metadata) string {
+	return m.Name.Space + ":" + m.Name.Local
+}
+
+func (d *differ) compareResources(from, to *go3mf.Resources) {
+	target := []errors.Level{level(from, -1)}
+	d.field(target, "AnyAttr", from.AnyAttr, to.AnyAttr)
+	for _, fa := range from.Assets {
+		lvl := append(target, level(fa, int(fa.Identify())))
+		ta, ok := findAsset(to.Assets, fa.Identify())
+		if !ok {
+			d.add(KindRemoved, lvl, "", fa, nil)
+		} else if !reflect.DeepEqual(fa, ta) {
+			d.add(KindChanged, lvl, "", fa, ta)
+		}
+	}
+	for _, ta := range to.Assets {
+		if _, ok := findAsset(from.Assets, ta.Identify()); !ok {
+			d.add(KindAdded, append(target, level(ta, int(ta.Identify()))), "", nil, ta)
+		}
+	}
+	for _, fo := range from.Objects {
+		lvl := append(target, level(fo, int(fo.ID)))
+		tobj, ok := findObject(to.Objects, fo.ID)
+		if !ok {
+			d.add(KindRemoved, lvl, "", fo, nil)
+		} else {
+			d.compareObject(lvl, fo, tobj)
+		}
+	}
+	for _, tobj := range to.Objects {
+		if _, ok := findObject(from.Objects, tobj.ID); !ok {
+			d.add(KindAdded, append(target, level(tobj, int(tobj.ID))), "", nil, tobj)
+		}
+	}
+}
+
+func findAsset(assets []go3mf.Asset, id uint32) (go3mf.Asset, bool) {
+	for _, a := range assets {
+		if a.Identify() == id {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+func findObject(objs []*go3mf.Object, id uint32) (*go3mf.Object, bool) {
+	for _, o := range objs {
+		if o.ID == id {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+func (d *differ) compareObject(target []errors.Level, from, to *go3mf.Object) {
+	d.field(target, "Name", from.Name, to.Name)
+	d.field(target, "PartNumber", from.PartNumber, to.PartNumber)
+	d.field(target, "Thumbnail", from.Thumbnail, to.Thumbnail)
+	d.field(target, "PID", from.PID, to.PID)
+	d.field(target, "PIndex", from.PIndex, to.PIndex)
+	d.field(target, "Type", from.Type, to.Type)
+	d.field(target, "AnyAttr", from.AnyAttr, to.AnyAttr)
+	d.field(target, "Any", from.Any, to.Any)
+	d.compareMetadata(target, from.Metadata, to.Metadata)
+	switch {
+	case from.Mesh != nil && to.Mesh == nil:
+		d.add(KindRemoved, append(target, level(from.Mesh, -1)), "", from.Mesh, nil)
+	case from.Mesh == nil && to.Mesh != nil:
+		d.add(KindAdded, append(target, level(to.Mesh, -1)), "", nil, to.Mesh)
+	case from.Mesh != nil:
+		d.compareMesh(append(target, level(from.Mesh, -1)), from.Mesh, to.Mesh)
+	}
+	for i, fc := range from.Components {
+		lvl := append(target, level(fc, i))
+		if i >= len(to.Components) {
+			d.add(KindRemoved, lvl, "", fc, nil)
+			continue
+		}
+		tc := to.Components[i]
+		d.field(lvl, "ObjectID", fc.ObjectID, tc.ObjectID)
+		if !d.equalMatrix(fc.Transform, tc.Transform) {
+			d.add(KindChanged, lvl, "Transform", fc.Transform, tc.Transform)
+		}
+		d.field(lvl, "AnyAttr", fc.AnyAttr, tc.AnyAttr)
+	}
+	for i := len(from.Components); i < len(to.Components); i++ {
+		d.add(KindAdded, append(target, level(to.Components[i], i)), "", nil, to.Components[i])
+	}
+}
+
+func (d *differ) compareMesh(target []errors.Level, from, to *go3mf.Mesh) {
+	nv, nt := len(from.Vertices), len(to.Vertices)
+	if i := d.firstVertexDiff(from.Vertices, to.Vertices); i < nv && i < nt {
+		d.add(KindChanged, append(target, level(from.Vertices[i], i)), "", from.Vertices[i], to.Vertices[i])
+	} else if i < nv {
+		d.add(KindRemoved, append(target, level(from.Vertices[i], i)), "", from.Vertices[i:], nil)
+	} else if i < nt {
+		d.add(KindAdded, append(target, level(to.Vertices[i], i)), "", nil, to.Vertices[i:])
+	}
+	nv, nt = len(from.Triangles), len(to.Triangles)
+	if i := firstTriangleDiff(from.Triangles, to.Triangles); i < nv && i < nt {
+		d.add(KindChanged, append(target, level(from.Triangles[i], i)), "", from.Triangles[i], to.Triangles[i])
+	} else if i < nv {
+		d.add(KindRemoved, append(target, level(from.Triangles[i], i)), "", from.Triangles[i:], nil)
+	} else if i < nt {
+		d.add(KindAdded, append(target, level(to.Triangles[i], i)), "", nil, to.Triangles[i:])
+	}
+	d.field(target, "TriangleSets", from.TriangleSets, to.TriangleSets)
+	d.field(target, "AnyAttr", from.AnyAttr, to.AnyAttr)
+	d.field(target, "Any", from.Any, to.Any)
+}
+
+func (d *differ) compareBuild(from, to *go3mf.Build) {
+	target := []errors.Level{level(from, -1)}
+	d.field(target, "AnyAttr", from.AnyAttr, to.AnyAttr)
+	for i, fi := range from.Items {
+		lvl := append(target, level(fi, i))
+		if i >= len(to.Items) {
+			d.add(KindRemoved, lvl, "", fi, nil)
+			continue
+		}
+		ti := to.Items[i]
+		d.field(lvl, "ObjectID", fi.ObjectID, ti.ObjectID)
+		if !d.equalMatrix(fi.Transform, ti.Transform) {
+			d.add(KindChanged, lvl, "Transform", fi.Transform, ti.Transform)
+		}
+		d.field(lvl, "PartNumber", fi.PartNumber, ti.PartNumber)
+		d.field(lvl, "AnyAttr", fi.AnyAttr, ti.AnyAttr)
+		d.compareMetadata(lvl, fi.Metadata, ti.Metadata)
+	}
+	for i := len(from.Items); i < len(to.Items); i++ {
+		d.add(KindAdded, append(target, level(to.Items[i], i)), "", nil, to.Items[i])
+	}
+}
+
+func (d *differ) compareRelationships(target []errors.Level, field string, from, to []go3mf.Relationship) {
+	find := func(rels []go3mf.Relationship, r *go3mf.Relationship) (int, bool) {
+		for i := range rels {
+			if strings.EqualFold(rels[i].Path, r.Path) && rels[i].Type == r.Type {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	for i := range from {
+		lvl := append(target, level(from[i], i))
+		j, ok := find(to, &from[i])
+		if !ok {
+			d.add(KindRemoved, lvl, field, from[i], nil)
+		} else if from[i].ID != to[j].ID {
+			d.add(KindChanged, lvl, field, from[i], to[j])
+		}
+	}
+	for j := range to {
+		if _, ok := find(from, &to[j]); !ok {
+			d.add(KindAdded, append(target, level(to[j], j)), field, nil, to[j])
+		}
+	}
+}
+
+func (d *differ) compareAttachments(from, to []go3mf.Attachment) {
+	find := func(atts []go3mf.Attachment, path string) (int, bool) {
+		for i := range atts {
+			if strings.EqualFold(atts[i].Path, path) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	for i := range from {
+		lvl := []errors.Level{level(from[i], i)}
+		j, ok := find(to, from[i].Path)
+		if !ok {
+			d.add(KindRemoved, lvl, "", from[i].Path, nil)
+			continue
+		}
+		d.field(lvl, "ContentType", from[i].ContentType, to[j].ContentType)
+		if d.opts.AttachmentContent {
+			fb, ferr := readAttachment(&from[i])
+			tb, terr := readAttachment(&to[j])
+			if ferr != nil || terr != nil || !bytes.Equal(fb, tb) {
+				d.add(KindChanged, lvl, "Stream", fb, tb)
+			}
+		}
+	}
+	for j := range to {
+		if _, ok := find(from, to[j].Path); !ok {
+			d.add(KindAdded, []errors.Level{level(to[j], j)}, "", nil, to[j].Path)
+		}
+	}
+}
+
+func readAttachment(a *go3mf.Attachment) ([]byte, error) {
+	if a.Stream == nil {
+		return nil, nil
+	}
+	r, err := a.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	a.Stream = bytes.NewReader(b)
+	return b, nil
+}
+
+func (d *differ) equalFloat(a, b float32) bool {
+	diff := a - b
+	return diff <= d.opts.Tolerance && -diff <= d.opts.Tolerance
+}
+
+// firstVertexDiff returns the index of the first different vertex,
+// which is the length of the shortest slice if one is a prefix of the other.
+func (d *differ) firstVertexDiff(from, to []go3mf.Point3D) int {
+	for i := range from {
+		if i >= len(to) {
+			return i
+		}
+		for j := 0; j < 3; j++ {
+			if !d.equalFloat(from[i][j], to[i][j]) {
+				return i
+			}
+		}
+	}
+	return len(from)
+}
+
+func (d *differ) equalMatrix(from, to go3mf.Matrix) bool {
+	if from == (go3mf.Matrix{}) {
+		from = go3mf.Identity()
+	}
+	if to == (go3mf.Matrix{}) {
+		to = go3mf.Identity()
+	}
+	for i := range from {
+		if !d.equalFloat(from[i], to[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// firstTriangleDiff is like firstVertexDiff but for triangles.
+func firstTriangleDiff(from, to []go3mf.Triangle) int {
+	for i := range from {
+		if i >= len(to) || from[i] != to[i] {
+			return i
+		}
+	}
+	return len(from)
+}
+
+func sortedKeys(a, b map[string]*go3mf.ChildModel) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
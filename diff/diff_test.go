@@ -0,0 +1,122 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io/ioutil"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+func newModel() *go3mf.Model {
+	return &go3mf.Model{
+		Units:    go3mf.UnitMillimeter,
+		Metadata: []go3mf.Metadata{{Name: xml.Name{Local: "Title"}, Value: "Cube"}},
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{&go3mf.BaseMaterials{ID: 1, Materials: []go3mf.Base{{Name: "Blue", Color: color.RGBA{0, 0, 255, 255}}}}},
+			Objects: []*go3mf.Object{
+				{ID: 2, Mesh: &go3mf.Mesh{
+					Vertices:  []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+					Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2)},
+				}},
+				{ID: 3, Components: []*go3mf.Component{{ObjectID: 2}}},
+			},
+		},
+		Build:         go3mf.Build{Items: []*go3mf.Item{{ObjectID: 3}}},
+		Relationships: []go3mf.Relationship{{Path: "/3D/Textures/a.png", Type: "texture"}},
+		Attachments:   []go3mf.Attachment{{Path: "/3D/Textures/a.png", ContentType: "image/png", Stream: bytes.NewBufferString("a")}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{{ID: 1, Name: "a"}}}},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   Options
+		modify func(*go3mf.Model)
+		want   []string
+	}{
+		{"equal", Options{}, func(*go3mf.Model) {}, nil},
+		{"units", Options{}, func(m *go3mf.Model) { m.Units = go3mf.UnitInch }, []string{"Units changed"}},
+		{"metadata", Options{}, func(m *go3mf.Model) {
+			m.Metadata[0].Value = "Box"
+			m.Metadata = append(m.Metadata, go3mf.Metadata{Name: xml.Name{Local: "Designer"}})
+		}, []string{"Metadata#0: Value changed", "Metadata#1: added"}},
+		{"metadataDuplicated", Options{}, func(m *go3mf.Model) {
+			m.Metadata = append(m.Metadata, m.Metadata[0])
+		}, []string{"Metadata#1: added"}},
+		{"asset", Options{}, func(m *go3mf.Model) {
+			m.Resources.Assets[0].(*go3mf.BaseMaterials).Materials[0].Name = "Red"
+		}, []string{"Resources@BaseMaterials#1: changed"}},
+		{"objects", Options{}, func(m *go3mf.Model) {
+			m.Resources.Objects[0].Name = "cube"
+			m.Resources.Objects = append(m.Resources.Objects[:1], &go3mf.Object{ID: 4})
+		}, []string{"Resources@Object#2: Name changed", "Resources@Object#3: removed", "Resources@Object#4: added"}},
+		{"mesh", Options{}, func(m *go3mf.Model) {
+			m.Resources.Objects[0].Mesh.Vertices[1] = go3mf.Point3D{1.001, 0, 0}
+			m.Resources.Objects[0].Mesh.Triangles[0] = go3mf.NewTriangle(0, 2, 1)
+		}, []string{"Resources@Object#2@Mesh@Point3D#1: changed", "Resources@Object#2@Mesh@Triangle#0: changed"}},
+		{"meshLength", Options{}, func(m *go3mf.Model) {
+			m.Resources.Objects[0].Mesh.Vertices = append(m.Resources.Objects[0].Mesh.Vertices, go3mf.Point3D{0, 0, 1})
+			m.Resources.Objects[0].Mesh.Triangles = nil
+		}, []string{"Resources@Object#2@Mesh@Point3D#3: added", "Resources@Object#2@Mesh@Triangle#0: removed"}},
+		{"meshTolerance", Options{Tolerance: 0.01}, func(m *go3mf.Model) {
+			m.Resources.Objects[0].Mesh.Vertices[1] = go3mf.Point3D{1.001, 0, 0}
+		}, nil},
+		{"components", Options{}, func(m *go3mf.Model) {
+			m.Resources.Objects[1].Components[0].Transform = go3mf.Matrix{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 10, 0, 0, 1}
+			m.Resources.Objects[1].Components = append(m.Resources.Objects[1].Components, &go3mf.Component{ObjectID: 2})
+		}, []string{"Resources@Object#3@Component#0: Transform changed", "Resources@Object#3@Component#1: added"}},
+		{"identity", Options{}, func(m *go3mf.Model) { m.Build.Items[0].Transform = go3mf.Identity() }, nil},
+		{"build", Options{}, func(m *go3mf.Model) {
+			m.Build.Items[0].PartNumber = "a"
+			m.Build.Items = append(m.Build.Items, &go3mf.Item{ObjectID: 2})
+		}, []string{"Build@Item#0: PartNumber changed", "Build@Item#1: added"}},
+		{"relationships", Options{}, func(m *go3mf.Model) {
+			m.Relationships[0].Path = "/3D/Textures/b.png"
+		}, []string{"Relationship#0: Relationships removed", "Relationship#0: Relationships added"}},
+		{"attachments", Options{}, func(m *go3mf.Model) {
+			m.Attachments[0].ContentType = "image/jpeg"
+			m.Attachments = append(m.Attachments, go3mf.Attachment{Path: "/thumb.png"})
+		}, []string{"Attachment#0: ContentType changed", "Attachment#1: added"}},
+		{"attachmentContent", Options{AttachmentContent: true}, func(m *go3mf.Model) {
+			m.Attachments[0].Stream = bytes.NewBufferString("b")
+		}, []string{"Attachment#0: Stream changed"}},
+		{"childs", Options{}, func(m *go3mf.Model) {
+			m.Childs["/3D/other.model"].Resources.Objects[0].Name = "b"
+			m.Childs["/3D/new.model"] = new(go3mf.ChildModel)
+		}, []string{"/3D/new.model: added", "/3D/other.model@Resources@Object#1: Name changed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			to := newModel()
+			tt.modify(to)
+			got := Compare(newModel(), to, tt.opts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Compare() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if s := got[i].String(); s != tt.want[i] {
+					t.Errorf("Compare()[%d] = %s, want %s", i, s, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompare_AttachmentContent(t *testing.T) {
+	from, to := newModel(), newModel()
+	for i := 0; i < 2; i++ {
+		if got := Compare(from, to, Options{AttachmentContent: true}); len(got) != 0 {
+			t.Errorf("Compare() = %v, want no changes", got)
+		}
+	}
+	b, err := ioutil.ReadAll(from.Attachments[0].Stream)
+	if err != nil || string(b) != "a" {
+		t.Errorf("Compare() attachment stream = %s, %v, want a", b, err)
+	}
+}
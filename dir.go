@@ -0,0 +1,262 @@
+package go3mf
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/qmuntal/opc"
+)
+
+const (
+	contentTypesName = "/[Content_Types].xml"
+	packageRelsName  = "/_rels/.rels"
+	contentTypeRels  = "application/vnd.openxmlformats-package.relationships+xml"
+
+	targetModeExternal = "External"
+)
+
+type xmlContentTypes struct {
+	XMLName   xml.Name      `xml:"http://schemas.openxmlformats.org/package/2006/content-types Types"`
+	Defaults  []xmlDefault  `xml:"Default"`
+	Overrides []xmlOverride `xml:"Override"`
+}
+
+type xmlDefault struct {
+	Extension   string `xml:"Extension,attr"`
+	ContentType string `xml:"ContentType,attr"`
+}
+
+type xmlOverride struct {
+	PartName    string `xml:"PartName,attr"`
+	ContentType string `xml:"ContentType,attr"`
+}
+
+type xmlRelationships struct {
+	XMLName       xml.Name          `xml:"http://schemas.openxmlformats.org/package/2006/relationships Relationships"`
+	Relationships []xmlRelationship `xml:"Relationship"`
+}
+
+type xmlRelationship struct {
+	ID         string `xml:"Id,attr"`
+	Type       string `xml:"Type,attr"`
+	Target     string `xml:"Target,attr"`
+	TargetMode string `xml:"TargetMode,attr,omitempty"`
+}
+
+// relsName returns the name of the part that contains
+// the relationships of the part with the given name.
+func relsName(name string) string {
+	dir, base := path.Split(name)
+	return dir + "_rels/" + base + ".rels"
+}
+
+// partExtension returns the lower case extension of name without the dot.
+func partExtension(name string) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))
+}
+
+func decodeRelationships(r io.Reader) ([]Relationship, error) {
+	var x xmlRelationships
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return nil, err
+	}
+	rels := make([]Relationship, 0, len(x.Relationships))
+	for _, r := range x.Relationships {
+		// External relationships do not target a part of the package.
+		if r.TargetMode == targetModeExternal {
+			continue
+		}
+		rels = append(rels, Relationship{ID: r.ID, Type: r.Type, Path: r.Target})
+	}
+	return rels, nil
+}
+
+func encodeRelationships(w io.Writer, rels []Relationship) error {
+	x := xmlRelationships{Relationships: make([]xmlRelationship, len(rels))}
+	ids := make(map[string]struct{}, len(rels))
+	for _, r := range rels {
+		ids[r.ID] = struct{}{}
+	}
+	var n int
+	for i, r := range rels {
+		id := r.ID
+		for id == "" {
+			n++
+			id = fmt.Sprintf("rId%d", n)
+			if _, ok := ids[id]; ok {
+				id = ""
+			}
+		}
+		x.Relationships[i] = xmlRelationship{ID: id, Type: r.Type, Target: r.Path}
+	}
+	return encodeXMLPart(w, x)
+}
+
+func encodeXMLPart(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
+
+// NewDirEncoder returns a new encoder that writes an unzipped package
+// into the directory dir, which is created if it does not exist.
+// Files already in dir are overwritten when the package contains them
+// but never removed, so dir should be empty to get a clean export.
+// All the relationships are written with the Internal target mode.
+func NewDirEncoder(dir string) *Encoder {
+	return &Encoder{
+		FloatPrecision: defaultFloatPrecision,
+		w:              newDirWriter(dir),
+	}
+}
+
+func newDirWriter(dir string) *fileWriter {
+	return &fileWriter{
+		// The compression level is ignored as parts are written uncompressed.
+		create: func(name string, _ Compression) (io.Writer, error) {
+			fpath := filepath.Join(dir, filepath.FromSlash(name))
+			if rel, err := filepath.Rel(dir, fpath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return nil, fmt.Errorf("go3mf: part %s is outside of the package directory", name)
+			}
+			if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+				return nil, err
+			}
+			return os.Create(fpath)
+		},
+	}
+}
+
+type filePart struct {
+	io.Writer
+	name          string
+	contentType   string
+	relationships []Relationship
+}
+
+func (p *filePart) AddRelationship(r Relationship) {
+	for _, ro := range p.relationships {
+		if ro.Type == r.Type && ro.Path == r.Path {
+			return
+		}
+	}
+	p.relationships = append(p.relationships, r)
+}
+
+// fileWriter implements a packageWriter that writes each part
+// as a file returned by create, encoding the relationships
+// and content types parts itself when closed.
+type fileWriter struct {
+	create        func(name string, c Compression) (io.Writer, error)
+	close         func() error
+	parts         []*filePart
+	relationships []Relationship
+	last          io.Closer
+}
+
+func (d *fileWriter) Create(name, contentType string, c Compression) (packagePart, error) {
+	if err := d.closeLast(); err != nil {
+		return nil, err
+	}
+	name = opc.NormalizePartName(name)
+	w, err := d.createFile(name, c)
+	if err != nil {
+		return nil, err
+	}
+	p := &filePart{Writer: w, name: name, contentType: contentType}
+	d.parts = append(d.parts, p)
+	return p, nil
+}
+
+func (d *fileWriter) AddRelationship(r Relationship) {
+	for _, ro := range d.relationships {
+		if ro.Type == r.Type && ro.Path == r.Path {
+			return
+		}
+	}
+	d.relationships = append(d.relationships, r)
+}
+
+func (d *fileWriter) Close() error {
+	if err := d.closeLast(); err != nil {
+		return err
+	}
+	for _, p := range d.parts {
+		if len(p.relationships) == 0 {
+			continue
+		}
+		if err := d.writeFile(relsName(p.name), func(w io.Writer) error {
+			return encodeRelationships(w, p.relationships)
+		}); err != nil {
+			return err
+		}
+	}
+	if err := d.writeFile(packageRelsName, func(w io.Writer) error {
+		return encodeRelationships(w, d.relationships)
+	}); err != nil {
+		return err
+	}
+	if err := d.writeFile(contentTypesName, d.encodeContentTypes); err != nil {
+		return err
+	}
+	if d.close != nil {
+		return d.close()
+	}
+	return nil
+}
+
+// encodeContentTypes uses the content type of the first part with a given extension
+// as the default for that extension, and overrides the parts that do not match it.
+func (d *fileWriter) encodeContentTypes(w io.Writer) error {
+	x := xmlContentTypes{Defaults: []xmlDefault{{Extension: "rels", ContentType: contentTypeRels}}}
+	defaults := map[string]string{"rels": contentTypeRels}
+	for _, p := range d.parts {
+		ext := partExtension(p.name)
+		if ct, ok := defaults[ext]; !ok && ext != "" {
+			defaults[ext] = p.contentType
+			x.Defaults = append(x.Defaults, xmlDefault{Extension: ext, ContentType: p.contentType})
+		} else if ct != p.contentType {
+			x.Overrides = append(x.Overrides, xmlOverride{PartName: p.name, ContentType: p.contentType})
+		}
+	}
+	sort.Slice(x.Defaults, func(i, j int) bool { return x.Defaults[i].Extension < x.Defaults[j].Extension })
+	return encodeXMLPart(w, x)
+}
+
+func (d *fileWriter) closeLast() error {
+	if d.last == nil {
+		return nil
+	}
+	err := d.last.Close()
+	d.last = nil
+	return err
+}
+
+func (d *fileWriter) createFile(name string, c Compression) (io.Writer, error) {
+	w, err := d.create(name, c)
+	if err != nil {
+		return nil, err
+	}
+	if wc, ok := w.(io.Closer); ok {
+		d.last = wc
+	}
+	return w, nil
+}
+
+func (d *fileWriter) writeFile(name string, fn func(io.Writer) error) error {
+	w, err := d.createFile(name, CompressionNormal)
+	if err != nil {
+		return err
+	}
+	err = fn(w)
+	if cerr := d.closeLast(); err == nil {
+		err = cerr
+	}
+	return err
+}
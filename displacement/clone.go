@@ -0,0 +1,31 @@
+package displacement
+
+import "github.com/qmuntal/go3mf"
+
+// Clone returns a deep copy of r.
+func (r *Displacement2D) Clone() interface{} {
+	c := *r
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *NormVectorGroup) Clone() interface{} {
+	c := *r
+	c.Vectors = append([]go3mf.Point3D(nil), r.Vectors...)
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *Disp2DGroup) Clone() interface{} {
+	c := *r
+	c.Coords = append([]Disp2DCoord(nil), r.Coords...)
+	return &c
+}
+
+// Clone returns a deep copy of m.
+func (m *DisplacementMesh) Clone() interface{} {
+	c := *m
+	c.Vertices = append([]go3mf.Point3D(nil), m.Vertices...)
+	c.Triangles = append([]Triangle(nil), m.Triangles...)
+	return &c
+}
@@ -0,0 +1,427 @@
+package displacement
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	switch parent := parent.(type) {
+	case *go3mf.Resources:
+		switch name {
+		case attrDisplacement2D:
+			child = &displacement2DDecoder{resources: parent}
+		case attrNormVectorGroup:
+			child = &normVectorGroupDecoder{resources: parent}
+		case attrDisp2DGroup:
+			child = &disp2DGroupDecoder{resources: parent}
+		}
+	case *go3mf.Object:
+		if name == attrDisplacementMesh {
+			child = &displacementMeshDecoder{resource: parent}
+		}
+	}
+	return
+}
+
+type displacement2DDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  Displacement2D
+}
+
+func (d *displacement2DDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *displacement2DDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.ID = uint32(id)
+		case attrPath:
+			d.resource.Path = string(a.Value)
+		case attrChannel:
+			var ok bool
+			if d.resource.Channel, ok = newChannel(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrTileStyleU:
+			d.resource.TileStyleU, _ = newTileStyle(string(a.Value))
+		case attrTileStyleV:
+			d.resource.TileStyleV, _ = newTileStyle(string(a.Value))
+		case attrFilter:
+			d.resource.Filter, _ = newTextureFilter(string(a.Value))
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type normVectorGroupDecoder struct {
+	baseDecoder
+	resources         *go3mf.Resources
+	resource          NormVectorGroup
+	normVectorDecoder normVectorDecoder
+}
+
+func (d *normVectorGroupDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *normVectorGroupDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *normVectorGroupDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrNormVector {
+		child = &d.normVectorDecoder
+	}
+	return
+}
+
+func (d *normVectorGroupDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.normVectorDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.ID = uint32(id)
+			break
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type normVectorDecoder struct {
+	baseDecoder
+	resource *NormVectorGroup
+}
+
+func (d *normVectorDecoder) Start(attrs []spec.Attr) error {
+	vector, errs := parsePoint3D(attrs)
+	d.resource.Vectors = append(d.resource.Vectors, vector)
+	if errs != nil {
+		return specerr.WrapIndex(errs, vector, len(d.resource.Vectors)-1)
+	}
+	return nil
+}
+
+type disp2DGroupDecoder struct {
+	baseDecoder
+	resources          *go3mf.Resources
+	resource           Disp2DGroup
+	disp2DCoordDecoder disp2DCoordDecoder
+}
+
+func (d *disp2DGroupDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *disp2DGroupDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *disp2DGroupDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrDisp2DCoord {
+		child = &d.disp2DCoordDecoder
+	}
+	return
+}
+
+func (d *disp2DGroupDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.disp2DCoordDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID, attrDispID, attrNID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			switch a.Name.Local {
+			case attrID:
+				d.resource.ID = uint32(val)
+			case attrDispID:
+				d.resource.DispID = uint32(val)
+			default:
+				d.resource.NormVecID = uint32(val)
+			}
+		case attrHeight:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			d.resource.Height = float32(val)
+		case attrOffset:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.Offset = float32(val)
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type disp2DCoordDecoder struct {
+	baseDecoder
+	resource *Disp2DGroup
+}
+
+func (d *disp2DCoordDecoder) Start(attrs []spec.Attr) error {
+	var (
+		coord = Disp2DCoord{F: 1}
+		errs  error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrU, attrV, attrF:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, a.Name.Local != attrF))
+			}
+			switch a.Name.Local {
+			case attrU:
+				coord.U = float32(val)
+			case attrV:
+				coord.V = float32(val)
+			default:
+				coord.F = float32(val)
+			}
+		case attrN:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			coord.N = uint32(val)
+		}
+	}
+	d.resource.Coords = append(d.resource.Coords, coord)
+	if errs != nil {
+		return specerr.WrapIndex(errs, coord, len(d.resource.Coords)-1)
+	}
+	return nil
+}
+
+type displacementMeshDecoder struct {
+	baseDecoder
+	resource *go3mf.Object
+	mesh     *DisplacementMesh
+}
+
+func (d *displacementMeshDecoder) Start(_ []spec.Attr) error {
+	d.mesh = new(DisplacementMesh)
+	d.resource.Any = append(d.resource.Any, d.mesh)
+	return nil
+}
+
+func (d *displacementMeshDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.mesh)
+}
+
+func (d *displacementMeshDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace {
+		if name.Local == attrVertices {
+			child = &verticesDecoder{mesh: d.mesh}
+		} else if name.Local == attrTriangles {
+			child = &trianglesDecoder{mesh: d.mesh}
+		}
+	}
+	return
+}
+
+type verticesDecoder struct {
+	baseDecoder
+	mesh          *DisplacementMesh
+	vertexDecoder vertexDecoder
+}
+
+func (d *verticesDecoder) Start(_ []spec.Attr) error {
+	d.vertexDecoder.mesh = d.mesh
+	return nil
+}
+
+func (d *verticesDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrVertex {
+		child = &d.vertexDecoder
+	}
+	return
+}
+
+type vertexDecoder struct {
+	baseDecoder
+	mesh *DisplacementMesh
+}
+
+func (d *vertexDecoder) Start(attrs []spec.Attr) error {
+	vertex, errs := parsePoint3D(attrs)
+	d.mesh.Vertices = append(d.mesh.Vertices, vertex)
+	if errs != nil {
+		return specerr.WrapIndex(errs, vertex, len(d.mesh.Vertices)-1)
+	}
+	return nil
+}
+
+type trianglesDecoder struct {
+	baseDecoder
+	mesh            *DisplacementMesh
+	triangleDecoder triangleDecoder
+}
+
+func (d *trianglesDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.triangleDecoder.mesh = d.mesh
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrDID {
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.triangleDecoder.defaultDID = uint32(val)
+			break
+		}
+	}
+	return errs
+}
+
+func (d *trianglesDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrTriangle {
+		child = &d.triangleDecoder
+	}
+	return
+}
+
+type triangleDecoder struct {
+	baseDecoder
+	mesh       *DisplacementMesh
+	defaultDID uint32
+}
+
+func (d *triangleDecoder) Start(attrs []spec.Attr) error {
+	var (
+		t                      = Triangle{DID: d.defaultDID}
+		hasD2, hasD3           bool
+		hasP2, hasP3           bool
+		errs                   error
+		required, hasRequiredV bool
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		required = false
+		switch a.Name.Local {
+		case attrV1:
+			t.Indices[0], required = uint32(val), true
+		case attrV2:
+			t.Indices[1], required = uint32(val), true
+		case attrV3:
+			t.Indices[2], required = uint32(val), true
+		case attrD1:
+			t.DIndices[0] = uint32(val)
+		case attrD2:
+			t.DIndices[1], hasD2 = uint32(val), true
+		case attrD3:
+			t.DIndices[2], hasD3 = uint32(val), true
+		case attrP1:
+			t.PIndices[0] = uint32(val)
+		case attrP2:
+			t.PIndices[1], hasP2 = uint32(val), true
+		case attrP3:
+			t.PIndices[2], hasP3 = uint32(val), true
+		case attrDID:
+			t.DID = uint32(val)
+		case attrPID:
+			t.PID = uint32(val)
+		default:
+			continue
+		}
+		hasRequiredV = hasRequiredV || required
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, required))
+		}
+	}
+	if !hasD2 {
+		t.DIndices[1] = t.DIndices[0]
+	}
+	if !hasD3 {
+		t.DIndices[2] = t.DIndices[0]
+	}
+	if !hasP2 {
+		t.PIndices[1] = t.PIndices[0]
+	}
+	if !hasP3 {
+		t.PIndices[2] = t.PIndices[0]
+	}
+	d.mesh.Triangles = append(d.mesh.Triangles, t)
+	if errs != nil {
+		return specerr.WrapIndex(errs, t, len(d.mesh.Triangles)-1)
+	}
+	return nil
+}
+
+func parsePoint3D(attrs []spec.Attr) (p go3mf.Point3D, errs error) {
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var i int
+		switch a.Name.Local {
+		case attrX:
+			i = 0
+		case attrY:
+			i = 1
+		case attrZ:
+			i = 2
+		default:
+			continue
+		}
+		val, err := strconv.ParseFloat(string(a.Value), 32)
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+		}
+		p[i] = float32(val)
+	}
+	return
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
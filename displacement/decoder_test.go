@@ -0,0 +1,141 @@
+package displacement
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestDecode(t *testing.T) {
+	disp := &Displacement2D{ID: 1, Path: "/3D/Textures/disp.png", Channel: ChannelR, TileStyleV: TileMirror, Filter: TextureFilterLinear}
+	norm := &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {1, 0, 0}}}
+	group := &Disp2DGroup{ID: 3, DispID: 1, NormVecID: 2, Height: 1.5, Offset: -0.5, Coords: []Disp2DCoord{
+		{U: 0.1, V: 0.2, N: 0, F: 1},
+		{U: 0.3, V: 0.4, N: 1, F: 0.5},
+	}}
+	mesh := &DisplacementMesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+		Triangles: []Triangle{
+			{Indices: [3]uint32{0, 1, 2}, DID: 3, DIndices: [3]uint32{0, 1, 1}},
+			{Indices: [3]uint32{0, 1, 3}, DID: 3, DIndices: [3]uint32{1, 1, 1}, PID: 4, PIndices: [3]uint32{1, 1, 1}},
+			{Indices: [3]uint32{0, 2, 3}, DID: 3},
+			{Indices: [3]uint32{1, 2, 3}},
+		},
+	}
+	want := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{disp, norm, group},
+			Objects: []*go3mf.Object{
+				{ID: 5, Name: "Disp", Any: go3mf.Any{mesh}},
+			},
+		},
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:d="http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07">
+		<resources>
+			<d:displacement2d id="1" path="/3D/Textures/disp.png" channel="R" tilestylev="mirror" filter="linear"/>
+			<d:normvectorgroup id="2">
+				<d:normvector x="0" y="0" z="1"/>
+				<d:normvector x="1" y="0" z="0"/>
+			</d:normvectorgroup>
+			<d:disp2dgroup id="3" dispid="1" nid="2" height="1.5" offset="-0.5">
+				<d:disp2dcoord u="0.1" v="0.2" n="0"/>
+				<d:disp2dcoord u="0.3" v="0.4" n="1" f="0.5"/>
+			</d:disp2dgroup>
+			<object id="5" name="Disp">
+				<d:displacementmesh>
+					<d:vertices>
+						<d:vertex x="0" y="0" z="0"/>
+						<d:vertex x="10" y="0" z="0"/>
+						<d:vertex x="0" y="10" z="0"/>
+						<d:vertex x="0" y="0" z="10"/>
+					</d:vertices>
+					<d:triangles did="3">
+						<d:triangle v1="0" v2="1" v3="2" d1="0" d2="1" d3="1"/>
+						<d:triangle v1="0" v2="1" v3="3" d1="1" pid="4" p1="1"/>
+						<d:triangle v1="0" v2="2" v3="3"/>
+						<d:triangle v1="1" v2="2" v3="3" did="0"/>
+					</d:triangles>
+				</d:displacementmesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModel() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@Displacement2D#0: %v", errors.NewParseAttrError("channel", false)),
+		fmt.Sprintf("Resources@NormVectorGroup#1: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@NormVectorGroup#1@Point3D#0: %v", errors.NewParseAttrError("x", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.NewParseAttrError("dispid", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.NewParseAttrError("height", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2@Disp2DCoord#0: %v", errors.NewParseAttrError("n", true)),
+		fmt.Sprintf("Resources@Disp2DGroup#2@Disp2DCoord#0: %v", errors.NewParseAttrError("f", false)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh@Point3D#0: %v", errors.NewParseAttrError("y", true)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh: %v", errors.NewParseAttrError("did", false)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#0: %v", errors.NewParseAttrError("v1", true)),
+		fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#0: %v", errors.NewParseAttrError("d2", false)),
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:d="http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07">
+		<resources>
+			<d:displacement2d id="a" path="/3D/Textures/disp.png" channel="C"/>
+			<d:normvectorgroup id="b">
+				<d:normvector x="a" y="0" z="1"/>
+			</d:normvectorgroup>
+			<d:disp2dgroup id="3" dispid="a" nid="2" height="b">
+				<d:disp2dcoord u="0.1" v="0.2" n="a" f="b"/>
+			</d:disp2dgroup>
+			<object id="5" name="Disp">
+				<d:displacementmesh>
+					<d:vertices>
+						<d:vertex x="0" y="a" z="0"/>
+					</d:vertices>
+					<d:triangles did="a">
+						<d:triangle v1="a" v2="1" v3="2" d1="0" d2="b"/>
+					</d:triangles>
+				</d:displacementmesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+		var errs []string
+		for _, err := range err.(*errors.List).Errors {
+			errs = append(errs, err.Error())
+		}
+		if diff := deep.Equal(errs, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
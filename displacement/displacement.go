@@ -0,0 +1,241 @@
+package displacement
+
+import (
+	"errors"
+
+	"github.com/qmuntal/go3mf"
+)
+
+const (
+	// Namespace is the canonical name of this extension.
+	Namespace = "http://schemas.microsoft.com/3dmanufacturing/displacement/2022/07"
+	// RelTypeTexture3D is the canonical 3D texture relationship type.
+	RelTypeTexture3D = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dtexture"
+)
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "d",
+	IsRequired: false,
+}
+
+var (
+	ErrMissingTexturePart   = errors.New("texture part MUST be added as an attachment")
+	ErrDisplacement2DRef    = errors.New("dispid MUST reference to a displacement2d resource")
+	ErrNormVectorGroupRef   = errors.New("nid MUST reference to a normvectorgroup resource")
+	ErrDisp2DGroupRef       = errors.New("did MUST reference to a disp2dgroup resource")
+	ErrZeroNormVector       = errors.New("a normalized displacement vector MUST NOT be zero")
+	ErrDisplacementObjType  = errors.New("a displacementmesh MUST only be added to an object of type model or solidsupport")
+	ErrDisplacementMeshOnly = errors.New("an object with a displacementmesh MUST NOT contain a mesh or components")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+type Spec struct{}
+
+// Channel defines the image channel used to sample the displacement.
+type Channel uint8
+
+// Supported channels.
+const (
+	ChannelG Channel = iota
+	ChannelR
+	ChannelB
+	ChannelA
+)
+
+func newChannel(s string) (c Channel, ok bool) {
+	c, ok = map[string]Channel{
+		"R": ChannelR,
+		"G": ChannelG,
+		"B": ChannelB,
+		"A": ChannelA,
+	}[s]
+	return
+}
+
+func (c Channel) String() string {
+	return map[Channel]string{
+		ChannelR: "R",
+		ChannelG: "G",
+		ChannelB: "B",
+		ChannelA: "A",
+	}[c]
+}
+
+// TileStyle defines the allowed tile styles.
+type TileStyle uint8
+
+// Supported tile style.
+const (
+	TileWrap TileStyle = iota
+	TileMirror
+	TileClamp
+	TileNone
+)
+
+func newTileStyle(s string) (t TileStyle, ok bool) {
+	t, ok = map[string]TileStyle{
+		"wrap":   TileWrap,
+		"mirror": TileMirror,
+		"clamp":  TileClamp,
+		"none":   TileNone,
+	}[s]
+	return
+}
+
+func (t TileStyle) String() string {
+	return map[TileStyle]string{
+		TileWrap:   "wrap",
+		TileMirror: "mirror",
+		TileClamp:  "clamp",
+		TileNone:   "none",
+	}[t]
+}
+
+// TextureFilter defines the allowed texture filters.
+type TextureFilter uint8
+
+// Supported texture filters.
+const (
+	TextureFilterAuto TextureFilter = iota
+	TextureFilterLinear
+	TextureFilterNearest
+)
+
+func newTextureFilter(s string) (t TextureFilter, ok bool) {
+	t, ok = map[string]TextureFilter{
+		"auto":    TextureFilterAuto,
+		"linear":  TextureFilterLinear,
+		"nearest": TextureFilterNearest,
+	}[s]
+	return
+}
+
+func (t TextureFilter) String() string {
+	return map[TextureFilter]string{
+		TextureFilterAuto:    "auto",
+		TextureFilterLinear:  "linear",
+		TextureFilterNearest: "nearest",
+	}[t]
+}
+
+// Displacement2D defines a PNG texture used to displace a surface.
+type Displacement2D struct {
+	ID         uint32
+	Path       string
+	Channel    Channel
+	TileStyleU TileStyle
+	TileStyleV TileStyle
+	Filter     TextureFilter
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Displacement2D) Identify() uint32 {
+	return r.ID
+}
+
+// NormVectorGroup acts as a container for the displacement vectors.
+type NormVectorGroup struct {
+	ID      uint32
+	Vectors []go3mf.Point3D
+}
+
+// Identify returns the unique ID of the resource.
+func (r *NormVectorGroup) Identify() uint32 {
+	return r.ID
+}
+
+// Disp2DCoord maps a vertex of a triangle to a position in the displacement texture.
+// N is the index of the displacement vector and F the displacement factor.
+type Disp2DCoord struct {
+	U, V float32
+	N    uint32
+	F    float32
+}
+
+// Disp2DGroup acts as a container for displacement coordinates.
+type Disp2DGroup struct {
+	ID        uint32
+	DispID    uint32
+	NormVecID uint32
+	Height    float32
+	Offset    float32
+	Coords    []Disp2DCoord
+}
+
+// Identify returns the unique ID of the resource.
+func (r *Disp2DGroup) Identify() uint32 {
+	return r.ID
+}
+
+// Triangle defines a triangle of a displacement mesh.
+// DID references the Disp2DGroup used by DIndices
+// and PID the property group used by PIndices.
+type Triangle struct {
+	Indices  [3]uint32
+	DIndices [3]uint32
+	PIndices [3]uint32
+	DID      uint32
+	PID      uint32
+}
+
+// DisplacementMesh is an alternative to the core mesh
+// whose surface is displaced by a texture.
+type DisplacementMesh struct {
+	Vertices  []go3mf.Point3D
+	Triangles []Triangle
+}
+
+// GetDisplacementMesh returns the displacement mesh of the object, if any.
+func GetDisplacementMesh(obj *go3mf.Object) *DisplacementMesh {
+	for _, a := range obj.Any {
+		if a, ok := a.(*DisplacementMesh); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+const (
+	attrDisplacement2D   = "displacement2d"
+	attrNormVectorGroup  = "normvectorgroup"
+	attrNormVector       = "normvector"
+	attrDisp2DGroup      = "disp2dgroup"
+	attrDisp2DCoord      = "disp2dcoord"
+	attrDisplacementMesh = "displacementmesh"
+	attrVertices         = "vertices"
+	attrVertex           = "vertex"
+	attrTriangles        = "triangles"
+	attrTriangle         = "triangle"
+	attrID               = "id"
+	attrPath             = "path"
+	attrChannel          = "channel"
+	attrTileStyleU       = "tilestyleu"
+	attrTileStyleV       = "tilestylev"
+	attrFilter           = "filter"
+	attrX                = "x"
+	attrY                = "y"
+	attrZ                = "z"
+	attrDispID           = "dispid"
+	attrNID              = "nid"
+	attrHeight           = "height"
+	attrOffset           = "offset"
+	attrU                = "u"
+	attrV                = "v"
+	attrN                = "n"
+	attrF                = "f"
+	attrV1               = "v1"
+	attrV2               = "v2"
+	attrV3               = "v3"
+	attrD1               = "d1"
+	attrD2               = "d2"
+	attrD3               = "d3"
+	attrP1               = "p1"
+	attrP2               = "p2"
+	attrP3               = "p3"
+	attrDID              = "did"
+	attrPID              = "pid"
+)
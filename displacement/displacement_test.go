@@ -0,0 +1,248 @@
+package displacement
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+var _ spec.Marshaler = new(Displacement2D)
+var _ spec.Marshaler = new(NormVectorGroup)
+var _ spec.Marshaler = new(Disp2DGroup)
+var _ spec.Marshaler = new(DisplacementMesh)
+var _ go3mf.Asset = new(Displacement2D)
+var _ go3mf.Asset = new(NormVectorGroup)
+var _ go3mf.Asset = new(Disp2DGroup)
+
+func TestChannel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Channel
+	}{
+		{"R", ChannelR},
+		{"G", ChannelG},
+		{"B", ChannelB},
+		{"A", ChannelA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.name {
+				t.Errorf("Channel.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newChannel(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantC  Channel
+		wantOk bool
+	}{
+		{"R", ChannelR, true},
+		{"G", ChannelG, true},
+		{"B", ChannelB, true},
+		{"A", ChannelA, true},
+		{"empty", ChannelG, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, gotOk := newChannel(tt.name)
+			if !reflect.DeepEqual(gotC, tt.wantC) {
+				t.Errorf("newChannel() gotC = %v, want %v", gotC, tt.wantC)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newChannel() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestTileStyle_String(t *testing.T) {
+	tests := []struct {
+		name string
+		t    TileStyle
+	}{
+		{"wrap", TileWrap},
+		{"mirror", TileMirror},
+		{"clamp", TileClamp},
+		{"none", TileNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.String(); got != tt.name {
+				t.Errorf("TileStyle.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newTileStyle(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantT  TileStyle
+		wantOk bool
+	}{
+		{"wrap", TileWrap, true},
+		{"mirror", TileMirror, true},
+		{"clamp", TileClamp, true},
+		{"none", TileNone, true},
+		{"empty", TileWrap, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotOk := newTileStyle(tt.name)
+			if !reflect.DeepEqual(gotT, tt.wantT) {
+				t.Errorf("newTileStyle() gotT = %v, want %v", gotT, tt.wantT)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newTileStyle() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestTextureFilter_String(t *testing.T) {
+	tests := []struct {
+		name string
+		t    TextureFilter
+	}{
+		{"auto", TextureFilterAuto},
+		{"linear", TextureFilterLinear},
+		{"nearest", TextureFilterNearest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.String(); got != tt.name {
+				t.Errorf("TextureFilter.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newTextureFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantT  TextureFilter
+		wantOk bool
+	}{
+		{"auto", TextureFilterAuto, true},
+		{"linear", TextureFilterLinear, true},
+		{"nearest", TextureFilterNearest, true},
+		{"empty", TextureFilterAuto, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotOk := newTextureFilter(tt.name)
+			if !reflect.DeepEqual(gotT, tt.wantT) {
+				t.Errorf("newTextureFilter() gotT = %v, want %v", gotT, tt.wantT)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newTextureFilter() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGetDisplacementMesh(t *testing.T) {
+	mesh := new(DisplacementMesh)
+	tests := []struct {
+		name string
+		obj  *go3mf.Object
+		want *DisplacementMesh
+	}{
+		{"empty", &go3mf.Object{}, nil},
+		{"other", &go3mf.Object{Any: go3mf.Any{nil}}, nil},
+		{"base", &go3mf.Object{Any: go3mf.Any{nil, mesh}}, mesh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDisplacementMesh(tt.obj); got != tt.want {
+				t.Errorf("GetDisplacementMesh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"normvectorgroup", func() spec.Cloner {
+			return &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}}}
+		}, func(c interface{}) { c.(*NormVectorGroup).Vectors[0] = go3mf.Point3D{1, 0, 0} }},
+		{"disp2dgroup", func() spec.Cloner {
+			return &Disp2DGroup{ID: 3, DispID: 1, NormVecID: 2, Height: 1, Coords: []Disp2DCoord{{U: 0.5, V: 0.5, N: 0, F: 1}}}
+		}, func(c interface{}) { c.(*Disp2DGroup).Coords[0].U = 1 }},
+		{"mesh", func() spec.Cloner {
+			return &DisplacementMesh{Vertices: []go3mf.Point3D{{0, 0, 0}}, Triangles: []Triangle{{Indices: [3]uint32{0, 1, 2}, DID: 3}}}
+		}, func(c interface{}) {
+			c.(*DisplacementMesh).Vertices[0] = go3mf.Point3D{1, 1, 1}
+			c.(*DisplacementMesh).Triangles[0].DID = 4
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"displacement2d", &Displacement2D{ID: 1, Path: "/a.png"}, &Displacement2D{ID: 11, Path: "/a.png_1"}},
+		{"normvectorgroup", &NormVectorGroup{ID: 2}, &NormVectorGroup{ID: 12}},
+		{"disp2dgroup", &Disp2DGroup{ID: 3, DispID: 1, NormVecID: 2}, &Disp2DGroup{ID: 13, DispID: 11, NormVecID: 12}},
+		{"mesh", &DisplacementMesh{Triangles: []Triangle{{DID: 3, PID: 4}}}, &DisplacementMesh{Triangles: []Triangle{{DID: 13, PID: 14}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name string
+		v    spec.Scaler
+		want interface{}
+	}{
+		{"disp2dgroup", &Disp2DGroup{Height: 1, Offset: 2}, &Disp2DGroup{Height: 2, Offset: 4}},
+		{"displacementmesh", &DisplacementMesh{Vertices: []go3mf.Point3D{{1, 2, 3}}}, &DisplacementMesh{Vertices: []go3mf.Point3D{{2, 4, 6}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.v.Scale(2)
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T.Scale() = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package displacement
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+// Marshal3MF encodes the resource.
+func (r *Displacement2D) Marshal3MF(x spec.Encoder) error {
+	x.AddRelationship(spec.Relationship{Path: r.Path, Type: RelTypeTexture3D})
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisplacement2D}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrPath}, Value: r.Path},
+	}}
+	if r.Channel != ChannelG {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrChannel}, Value: r.Channel.String()})
+	}
+	if r.TileStyleU != TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleU}, Value: r.TileStyleU.String()})
+	}
+	if r.TileStyleV != TileWrap {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrTileStyleV}, Value: r.TileStyleV.String()})
+	}
+	if r.Filter != TextureFilterAuto {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrFilter}, Value: r.Filter.String()})
+	}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *NormVectorGroup) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrNormVectorGroup}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	marshalPoints(x, attrNormVector, r.Vectors)
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *Disp2DGroup) Marshal3MF(x spec.Encoder) error {
+	prec := x.FloatPresicion()
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisp2DGroup}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrDispID}, Value: strconv.FormatUint(uint64(r.DispID), 10)},
+		{Name: xml.Name{Local: attrNID}, Value: strconv.FormatUint(uint64(r.NormVecID), 10)},
+		{Name: xml.Name{Local: attrHeight}, Value: strconv.FormatFloat(float64(r.Height), 'f', prec, 32)},
+	}}
+	if r.Offset != 0 {
+		xs.Attr = append(xs.Attr, xml.Attr{Name: xml.Name{Local: attrOffset}, Value: strconv.FormatFloat(float64(r.Offset), 'f', prec, 32)})
+	}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	for _, c := range r.Coords {
+		start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisp2DCoord}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrU}, Value: strconv.FormatFloat(float64(c.U), 'f', prec, 32)},
+			{Name: xml.Name{Local: attrV}, Value: strconv.FormatFloat(float64(c.V), 'f', prec, 32)},
+			{Name: xml.Name{Local: attrN}, Value: strconv.FormatUint(uint64(c.N), 10)},
+		}}
+		if c.F != 1 {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attrF}, Value: strconv.FormatFloat(float64(c.F), 'f', prec, 32)})
+		}
+		x.EncodeToken(start)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (m *DisplacementMesh) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrDisplacementMesh}}
+	x.EncodeToken(xs)
+
+	xv := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVertices}}
+	x.EncodeToken(xv)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	marshalPoints(x, attrVertex, m.Vertices)
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xv.End())
+
+	marshalTriangles(x, m)
+
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+func marshalTriangles(x spec.Encoder, m *DisplacementMesh) {
+	xt := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTriangles}}
+	x.EncodeToken(xt)
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	for _, t := range m.Triangles {
+		start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTriangle}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrV1}, Value: strconv.FormatUint(uint64(t.Indices[0]), 10)},
+			{Name: xml.Name{Local: attrV2}, Value: strconv.FormatUint(uint64(t.Indices[1]), 10)},
+			{Name: xml.Name{Local: attrV3}, Value: strconv.FormatUint(uint64(t.Indices[2]), 10)},
+		}}
+		if t.DID != 0 {
+			start.Attr = append(start.Attr,
+				xml.Attr{Name: xml.Name{Local: attrDID}, Value: strconv.FormatUint(uint64(t.DID), 10)},
+				xml.Attr{Name: xml.Name{Local: attrD1}, Value: strconv.FormatUint(uint64(t.DIndices[0]), 10)},
+			)
+			if t.DIndices[1] != t.DIndices[0] || t.DIndices[2] != t.DIndices[0] {
+				start.Attr = append(start.Attr,
+					xml.Attr{Name: xml.Name{Local: attrD2}, Value: strconv.FormatUint(uint64(t.DIndices[1]), 10)},
+					xml.Attr{Name: xml.Name{Local: attrD3}, Value: strconv.FormatUint(uint64(t.DIndices[2]), 10)},
+				)
+			}
+		}
+		if t.PID != 0 {
+			start.Attr = append(start.Attr,
+				xml.Attr{Name: xml.Name{Local: attrPID}, Value: strconv.FormatUint(uint64(t.PID), 10)},
+				xml.Attr{Name: xml.Name{Local: attrP1}, Value: strconv.FormatUint(uint64(t.PIndices[0]), 10)},
+			)
+			if t.PIndices[1] != t.PIndices[0] || t.PIndices[2] != t.PIndices[0] {
+				start.Attr = append(start.Attr,
+					xml.Attr{Name: xml.Name{Local: attrP2}, Value: strconv.FormatUint(uint64(t.PIndices[1]), 10)},
+					xml.Attr{Name: xml.Name{Local: attrP3}, Value: strconv.FormatUint(uint64(t.PIndices[2]), 10)},
+				)
+			}
+		}
+		x.EncodeToken(start)
+	}
+	x.SetSkipAttrEscape(false)
+	x.SetAutoClose(false)
+	x.EncodeToken(xt.End())
+}
+
+func marshalPoints(x spec.Encoder, name string, points []go3mf.Point3D) {
+	prec := x.FloatPresicion()
+	start := xml.StartElement{Name: xml.Name{Space: Namespace, Local: name}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrX}},
+		{Name: xml.Name{Local: attrY}},
+		{Name: xml.Name{Local: attrZ}},
+	}}
+	for _, p := range points {
+		start.Attr[0].Value = strconv.FormatFloat(float64(p.X()), 'f', prec, 32)
+		start.Attr[1].Value = strconv.FormatFloat(float64(p.Y()), 'f', prec, 32)
+		start.Attr[2].Value = strconv.FormatFloat(float64(p.Z()), 'f', prec, 32)
+		x.EncodeToken(start)
+	}
+}
@@ -0,0 +1,53 @@
+package displacement
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestMarshalModel(t *testing.T) {
+	disp := &Displacement2D{ID: 1, Path: "/3D/Textures/disp.png", Channel: ChannelA, TileStyleU: TileClamp, TileStyleV: TileNone, Filter: TextureFilterNearest}
+	norm := &NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {0.5, 0.5, 0}}}
+	group := &Disp2DGroup{ID: 3, DispID: 1, NormVecID: 2, Height: 2, Offset: 0.5, Coords: []Disp2DCoord{
+		{U: 0.1, V: 0.2, N: 0, F: 1},
+		{U: 0.3, V: 0.4, N: 1, F: 0.25},
+	}}
+	mesh := &DisplacementMesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+		Triangles: []Triangle{
+			{Indices: [3]uint32{0, 1, 2}, DID: 3, DIndices: [3]uint32{0, 1, 1}},
+			{Indices: [3]uint32{0, 1, 3}, DID: 3, DIndices: [3]uint32{1, 1, 1}, PID: 4, PIndices: [3]uint32{1, 2, 0}},
+			{Indices: [3]uint32{0, 2, 3}, PID: 4, PIndices: [3]uint32{2, 2, 2}},
+			{Indices: [3]uint32{1, 2, 3}},
+		},
+	}
+	m := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{disp, norm, group},
+			Objects: []*go3mf.Object{
+				{ID: 5, Name: "Disp", Type: go3mf.ObjectTypeSolidSupport, Any: go3mf.Any{mesh}},
+			},
+		},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("displacement.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("displacement.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("displacement.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
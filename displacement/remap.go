@@ -0,0 +1,31 @@
+package displacement
+
+// RemapIDs replaces the resource ID with the one returned by fn.
+func (r *Displacement2D) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
+
+// RemapPaths replaces the texture path with the one returned by fn.
+func (r *Displacement2D) RemapPaths(fn func(string) string) {
+	r.Path = fn(r.Path)
+}
+
+// RemapIDs replaces the resource ID with the one returned by fn.
+func (r *NormVectorGroup) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *Disp2DGroup) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.DispID = fn(r.DispID)
+	r.NormVecID = fn(r.NormVecID)
+}
+
+// RemapIDs replaces the resource IDs referenced by the triangles with the ones returned by fn.
+func (m *DisplacementMesh) RemapIDs(fn func(uint32) uint32) {
+	for i := range m.Triangles {
+		m.Triangles[i].DID = fn(m.Triangles[i].DID)
+		m.Triangles[i].PID = fn(m.Triangles[i].PID)
+	}
+}
@@ -0,0 +1,16 @@
+package displacement
+
+import "github.com/qmuntal/go3mf"
+
+// Scale multiplies the displacement height and offset by factor.
+func (r *Disp2DGroup) Scale(factor float32) {
+	r.Height *= factor
+	r.Offset *= factor
+}
+
+// Scale multiplies the vertices by factor.
+func (m *DisplacementMesh) Scale(factor float32) {
+	for i, v := range m.Vertices {
+		m.Vertices[i] = go3mf.Point3D{v[0] * factor, v[1] * factor, v[2] * factor}
+	}
+}
@@ -0,0 +1,180 @@
+package displacement
+
+import (
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+func (Spec) Validate(model interface{}, path string, element interface{}) error {
+	switch element := element.(type) {
+	case *go3mf.Object:
+		return validateObject(model.(*go3mf.Model), path, element)
+	case go3mf.Asset:
+		return validateAsset(model.(*go3mf.Model), path, element)
+	}
+	return nil
+}
+
+func validateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
+	switch r := r.(type) {
+	case *Displacement2D:
+		errs = validateDisplacement2D(m, r)
+	case *NormVectorGroup:
+		errs = validateNormVectorGroup(r)
+	case *Disp2DGroup:
+		errs = validateDisp2DGroup(m, path, r)
+	}
+	return
+}
+
+func validateDisplacement2D(m *go3mf.Model, r *Displacement2D) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Path == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrPath))
+	} else {
+		var hasTexture bool
+		for _, a := range m.Attachments {
+			if strings.EqualFold(a.Path, r.Path) {
+				hasTexture = true
+				break
+			}
+		}
+		if !hasTexture {
+			errs = errors.Append(errs, ErrMissingTexturePart)
+		}
+	}
+	return
+}
+
+func validateNormVectorGroup(r *NormVectorGroup) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Vectors) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for i, v := range r.Vectors {
+		if v == (go3mf.Point3D{}) {
+			errs = errors.Append(errs, errors.WrapIndex(ErrZeroNormVector, v, i))
+		}
+	}
+	return
+}
+
+func validateDisp2DGroup(m *go3mf.Model, path string, r *Disp2DGroup) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.DispID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrDispID))
+	} else if a, ok := m.FindAsset(path, r.DispID); ok {
+		if _, ok := a.(*Displacement2D); !ok {
+			errs = errors.Append(errs, ErrDisplacement2DRef)
+		}
+	} else {
+		errs = errors.Append(errs, ErrDisplacement2DRef)
+	}
+	var vectors = -1
+	if r.NormVecID == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrNID))
+	} else if a, ok := m.FindAsset(path, r.NormVecID); ok {
+		if a, ok := a.(*NormVectorGroup); ok {
+			vectors = len(a.Vectors)
+		} else {
+			errs = errors.Append(errs, ErrNormVectorGroupRef)
+		}
+	} else {
+		errs = errors.Append(errs, ErrNormVectorGroupRef)
+	}
+	if len(r.Coords) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	if vectors >= 0 {
+		for i, c := range r.Coords {
+			if int(c.N) >= vectors {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, c, i))
+			}
+		}
+	}
+	return
+}
+
+func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	mesh := GetDisplacementMesh(obj)
+	if mesh == nil {
+		return nil
+	}
+	var errs error
+	if obj.Type != go3mf.ObjectTypeModel && obj.Type != go3mf.ObjectTypeSolidSupport {
+		errs = errors.Append(errs, ErrDisplacementObjType)
+	}
+	if obj.Mesh != nil || len(obj.Components) > 0 {
+		errs = errors.Append(errs, ErrDisplacementMeshOnly)
+	}
+	if obj.PID != 0 {
+		if a, ok := m.FindAsset(path, obj.PID); ok {
+			if a, ok := a.(spec.PropertyGroup); ok {
+				if int(obj.PIndex) >= a.Len() {
+					errs = errors.Append(errs, errors.ErrIndexOutOfBounds)
+				}
+			}
+		} else {
+			errs = errors.Append(errs, errors.ErrMissingResource)
+		}
+	}
+	if err := validateMesh(m, path, mesh); err != nil {
+		errs = errors.Append(errs, errors.Wrap(err, mesh))
+	}
+	return errs
+}
+
+func validateMesh(m *go3mf.Model, path string, mesh *DisplacementMesh) (errs error) {
+	if len(mesh.Vertices) < 3 {
+		errs = errors.Append(errs, errors.ErrInsufficientVertices)
+	}
+	if len(mesh.Triangles) <= 3 {
+		errs = errors.Append(errs, errors.ErrInsufficientTriangles)
+	}
+	nodeCount := uint32(len(mesh.Vertices))
+	for i, t := range mesh.Triangles {
+		i0, i1, i2 := t.Indices[0], t.Indices[1], t.Indices[2]
+		if i0 == i1 || i0 == i2 || i1 == i2 {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrDuplicatedIndices, t, i))
+		}
+		if i0 >= nodeCount || i1 >= nodeCount || i2 >= nodeCount {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+		}
+		if t.DID != 0 {
+			if a, ok := m.FindAsset(path, t.DID); ok {
+				if a, ok := a.(*Disp2DGroup); ok {
+					l := uint32(len(a.Coords))
+					if t.DIndices[0] >= l || t.DIndices[1] >= l || t.DIndices[2] >= l {
+						errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+					}
+				} else {
+					errs = errors.Append(errs, errors.WrapIndex(ErrDisp2DGroupRef, t, i))
+				}
+			} else {
+				errs = errors.Append(errs, errors.WrapIndex(ErrDisp2DGroupRef, t, i))
+			}
+		}
+		if t.PID != 0 {
+			if a, ok := m.FindAsset(path, t.PID); ok {
+				if a, ok := a.(spec.PropertyGroup); ok {
+					l := uint32(a.Len())
+					if t.PIndices[0] >= l || t.PIndices[1] >= l || t.PIndices[2] >= l {
+						errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, t, i))
+					}
+				}
+			} else {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrMissingResource, t, i))
+			}
+		}
+	}
+	return
+}
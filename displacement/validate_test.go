@@ -0,0 +1,108 @@
+package displacement
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestValidate(t *testing.T) {
+	validMesh := func() *DisplacementMesh {
+		return &DisplacementMesh{
+			Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+			Triangles: []Triangle{
+				{Indices: [3]uint32{0, 1, 2}}, {Indices: [3]uint32{0, 1, 3}},
+				{Indices: [3]uint32{0, 2, 3}}, {Indices: [3]uint32{1, 2, 3}},
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []string
+	}{
+		{"empty resources", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&Displacement2D{}, &NormVectorGroup{}, &Disp2DGroup{},
+		}}}, []string{
+			fmt.Sprintf("Resources@Displacement2D#0: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@Displacement2D#0: %v", &errors.MissingFieldError{Name: attrPath}),
+			fmt.Sprintf("Resources@NormVectorGroup#1: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@NormVectorGroup#1: %v", errors.ErrEmptyResourceProps),
+			fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@Disp2DGroup#2: %v", &errors.MissingFieldError{Name: attrDispID}),
+			fmt.Sprintf("Resources@Disp2DGroup#2: %v", &errors.MissingFieldError{Name: attrNID}),
+			fmt.Sprintf("Resources@Disp2DGroup#2: %v", errors.ErrEmptyResourceProps),
+		}},
+		{"invalid references", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&Displacement2D{ID: 1, Path: "/a.png"},
+			&NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}, {}}},
+			&Disp2DGroup{ID: 3, DispID: 2, NormVecID: 1, Coords: []Disp2DCoord{{}}},
+			&Disp2DGroup{ID: 4, DispID: 100, NormVecID: 100, Coords: []Disp2DCoord{{}}},
+			&Disp2DGroup{ID: 5, DispID: 1, NormVecID: 2, Coords: []Disp2DCoord{{N: 1}, {N: 2}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Displacement2D#0: %v", ErrMissingTexturePart),
+			fmt.Sprintf("Resources@NormVectorGroup#1@Point3D#1: %v", ErrZeroNormVector),
+			fmt.Sprintf("Resources@Disp2DGroup#2: %v", ErrDisplacement2DRef),
+			fmt.Sprintf("Resources@Disp2DGroup#2: %v", ErrNormVectorGroupRef),
+			fmt.Sprintf("Resources@Disp2DGroup#3: %v", ErrDisplacement2DRef),
+			fmt.Sprintf("Resources@Disp2DGroup#3: %v", ErrNormVectorGroupRef),
+			fmt.Sprintf("Resources@Disp2DGroup#4@Disp2DCoord#1: %v", errors.ErrIndexOutOfBounds),
+		}},
+		{"invalid object", &go3mf.Model{Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Type: go3mf.ObjectTypeSupport, Any: go3mf.Any{validMesh()}},
+			{ID: 2, Mesh: &go3mf.Mesh{}, Any: go3mf.Any{validMesh()}},
+			{ID: 3, PID: 100, Any: go3mf.Any{validMesh()}},
+			{ID: 4, Any: go3mf.Any{&DisplacementMesh{}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0: %v", ErrDisplacementObjType),
+			fmt.Sprintf("Resources@Object#1@Mesh: %v", errors.ErrInsufficientVertices),
+			fmt.Sprintf("Resources@Object#1@Mesh: %v", errors.ErrInsufficientTriangles),
+			fmt.Sprintf("Resources@Object#1: %v", ErrDisplacementMeshOnly),
+			fmt.Sprintf("Resources@Object#2: %v", errors.ErrMissingResource),
+			fmt.Sprintf("Resources@Object#3@DisplacementMesh: %v", errors.ErrInsufficientVertices),
+			fmt.Sprintf("Resources@Object#3@DisplacementMesh: %v", errors.ErrInsufficientTriangles),
+		}},
+		{"invalid triangles", &go3mf.Model{Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&Displacement2D{ID: 1, Path: "/a.png"},
+				&NormVectorGroup{ID: 2, Vectors: []go3mf.Point3D{{0, 0, 1}}},
+				&Disp2DGroup{ID: 3, DispID: 1, NormVecID: 2, Coords: []Disp2DCoord{{}}},
+			},
+			Objects: []*go3mf.Object{
+				{ID: 4, Any: go3mf.Any{&DisplacementMesh{
+					Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+					Triangles: []Triangle{
+						{Indices: [3]uint32{0, 0, 2}}, {Indices: [3]uint32{0, 1, 4}},
+						{Indices: [3]uint32{0, 2, 3}, DID: 2}, {Indices: [3]uint32{1, 2, 3}, DID: 3, DIndices: [3]uint32{0, 1, 0}},
+						{Indices: [3]uint32{1, 2, 3}, PID: 100},
+					},
+				}}},
+			},
+		}, Attachments: []go3mf.Attachment{{Path: "/a.png"}}}, []string{
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#0: %v", errors.ErrDuplicatedIndices),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#1: %v", errors.ErrIndexOutOfBounds),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#2: %v", ErrDisp2DGroupRef),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#3: %v", errors.ErrIndexOutOfBounds),
+			fmt.Sprintf("Resources@Object#0@DisplacementMesh@Triangle#4: %v", errors.ErrMissingResource),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.Extensions = []go3mf.Extension{DefaultExtension}
+			err := tt.model.Validate()
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			var errs []string
+			for _, err := range err.(*errors.List).Errors {
+				errs = append(errs, err.Error())
+			}
+			if diff := deep.Equal(errs, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
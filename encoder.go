@@ -76,7 +76,7 @@ type packagePart interface {
 }
 
 type packageWriter interface {
-	Create(name, contentType string) (packagePart, error)
+	Create(name, contentType string, c Compression) (packagePart, error)
 	AddRelationship(Relationship)
 	Close() error
 }
@@ -90,12 +90,41 @@ func MarshalModel(m *Model) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// Compression defines the compression level of a package part.
+type Compression int
+
+// Supported compression levels.
+const (
+	CompressionNormal    Compression = iota // Compromise between size and performance.
+	CompressionNone                         // Parts are stored without compression.
+	CompressionMaximum                      // Optimized for size.
+	CompressionFast                         // Optimized for performance.
+	CompressionSuperFast                    // Optimized for super performance.
+)
+
 // An Encoder writes Model data to an output stream.
 //
 // See the documentation for strconv.FormatFloat for details about the FloatPrecision behaviour.
+//
+// If Encrypter is not nil it is used to encrypt the parts protected
+// by the Secure Content extension and to write the package key store.
+//
+// If Deterministic is true the same Model is always encoded into the same bytes:
+// parts are written in a stable order, empty relationship IDs are derived from
+// their position and the zip timestamps are fixed. Encrypted parts are still random.
+// Encoders returned by NewDirEncoder always behave this way.
+//
+// Compression is the compression level used for every part.
+// ContentTypeCompression overrides it for the parts with a given content type
+// and CompressionFunc, if not nil, overrides both for the parts it reports.
 type Encoder struct {
-	FloatPrecision int
-	w              packageWriter
+	FloatPrecision         int
+	Encrypter              Encrypter
+	Deterministic          bool
+	Compression            Compression
+	ContentTypeCompression map[string]Compression
+	CompressionFunc        func(name, contentType string) (Compression, bool)
+	w                      packageWriter
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -108,6 +137,27 @@ func NewEncoder(w io.Writer) *Encoder {
 
 // Encode writes the XML encoding of m to the stream.
 func (e *Encoder) Encode(m *Model) error {
+	// Work on a copy so the writer built for this call
+	// does not leak into the next one.
+	ce := *e
+	ce.w = e.writer()
+	return ce.encode(m)
+}
+
+// writer returns the package writer used by a single Encode call.
+func (e *Encoder) writer() packageWriter {
+	w := e.w
+	if o, ok := w.(*opcWriter); ok && e.Deterministic {
+		// opc.Writer stamps the current time and random relationship IDs.
+		w = newZipWriter(o.out)
+	}
+	if e.Encrypter != nil {
+		w = &encryptWriter{packageWriter: w, e: e.Encrypter, compression: e.compression}
+	}
+	return w
+}
+
+func (e *Encoder) encode(m *Model) error {
 	if err := e.writeAttachements(m.Attachments); err != nil {
 		return err
 	}
@@ -117,7 +167,7 @@ func (e *Encoder) Encode(m *Model) error {
 	}
 	e.w.AddRelationship(Relationship{Type: RelType3DModel, Path: rootName})
 
-	w, err := e.w.Create(rootName, ContentType3DModel)
+	w, err := e.create(rootName, ContentType3DModel)
 	if err != nil {
 		return err
 	}
@@ -127,7 +177,7 @@ func (e *Encoder) Encode(m *Model) error {
 	enc := newXMLEncoder(w, e.FloatPrecision)
 	enc.relationships = make([]Relationship, len(m.Relationships))
 	copy(enc.relationships, m.Relationships)
-	for path := range m.Childs {
+	for _, path := range m.childPaths() {
 		enc.AddRelationship(spec.Relationship{Type: RelType3DModel, Path: path})
 	}
 	if err = e.writeModel(enc, m); err != nil {
@@ -143,14 +193,31 @@ func (e *Encoder) Encode(m *Model) error {
 	return e.w.Close()
 }
 
+func (e *Encoder) create(name, contentType string) (packagePart, error) {
+	return e.w.Create(name, contentType, e.compression(name, contentType))
+}
+
+func (e *Encoder) compression(name, contentType string) Compression {
+	if e.CompressionFunc != nil {
+		if c, ok := e.CompressionFunc(name, contentType); ok {
+			return c
+		}
+	}
+	if c, ok := e.ContentTypeCompression[contentType]; ok {
+		return c
+	}
+	return e.Compression
+}
+
 func (e *Encoder) writeChildModels(m *Model) error {
-	for path, child := range m.Childs {
+	for _, path := range m.childPaths() {
+		child := m.Childs[path]
 		var (
 			w   packagePart
 			err error
 		)
 		path = resolveRelationship(m.PathOrDefault(), path)
-		if w, err = e.w.Create(path, ContentType3DModel); err != nil {
+		if w, err = e.create(path, ContentType3DModel); err != nil {
 			return err
 		}
 		if _, err = w.Write([]byte(xml.Header)); err != nil {
@@ -170,10 +237,16 @@ func (e *Encoder) writeChildModels(m *Model) error {
 
 func (e *Encoder) writeAttachements(att []Attachment) error {
 	for _, a := range att {
-		w, err := e.w.Create(a.Path, a.ContentType)
-		if err == nil {
-			_, err = io.Copy(w, a.Stream)
+		w, err := e.create(a.Path, a.ContentType)
+		if err != nil {
+			return err
+		}
+		r, err := a.Open()
+		if err != nil {
+			return err
 		}
+		_, err = io.Copy(w, r)
+		r.Close()
 		if err != nil {
 			return err
 		}
@@ -181,6 +254,36 @@ func (e *Encoder) writeAttachements(att []Attachment) error {
 	return nil
 }
 
+// childPaths returns the paths of the child models sorted.
+func (m *Model) childPaths() []string {
+	paths := make([]string, 0, len(m.Childs))
+	for path := range m.Childs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func (m *Model) hasTriangleSets() bool {
+	hasSets := func(objs []*Object) bool {
+		for _, o := range objs {
+			if o.Mesh != nil && len(o.Mesh.TriangleSets) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+	if hasSets(m.Resources.Objects) {
+		return true
+	}
+	for _, c := range m.Childs {
+		if hasSets(c.Resources.Objects) {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Encoder) modelToken(x spec.Encoder, m *Model, isRoot bool) (xml.StartElement, error) {
 	attrs := []xml.Attr{
 		{Name: xml.Name{Local: attrXmlns}, Value: Namespace},
@@ -193,8 +296,13 @@ func (e *Encoder) modelToken(x spec.Encoder, m *Model, isRoot bool) (xml.StartEl
 		}
 		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: attrThumbnail}, Value: m.Thumbnail})
 	}
+	var hasTriangleSetsNs bool
 	for _, ext := range m.Extensions {
 		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: attrXmlns, Local: ext.LocalName}, Value: ext.Namespace})
+		hasTriangleSetsNs = hasTriangleSetsNs || ext.Namespace == NamespaceTriangleSets
+	}
+	if !hasTriangleSetsNs && m.hasTriangleSets() {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: attrXmlns, Local: "t"}, Value: NamespaceTriangleSets})
 	}
 	var exts []string
 	for _, ext := range m.Extensions {
@@ -222,7 +330,9 @@ func (e *Encoder) writeChildModel(x spec.Encoder, m *Model, child *ChildModel) e
 	xb := xml.StartElement{Name: xml.Name{Local: attrBuild}}
 	x.EncodeToken(xb)
 	x.EncodeToken(xb.End())
-	child.Any.encode(x)
+	if err := child.Any.encode(x); err != nil {
+		return err
+	}
 	x.EncodeToken(tm.End())
 	return x.Flush()
 }
@@ -239,7 +349,9 @@ func (e *Encoder) writeModel(x spec.Encoder, m *Model) error {
 		return err
 	}
 	e.writeBuild(x, m)
-	m.Any.encode(x)
+	if err := m.Any.encode(x); err != nil {
+		return err
+	}
 	x.EncodeToken(tm.End())
 	return x.Flush()
 }
@@ -300,7 +412,9 @@ func (e *Encoder) writeResources(x spec.Encoder, rs *Resources) error {
 	}
 
 	for _, o := range rs.Objects {
-		e.writeObject(x, o)
+		if err := e.writeObject(x, o); err != nil {
+			return err
+		}
 		if err := x.Flush(); err != nil {
 			return err
 		}
@@ -334,7 +448,7 @@ func (e *Encoder) writeMetadata(x spec.Encoder, metadata []Metadata) {
 	}
 }
 
-func (e *Encoder) writeObject(x spec.Encoder, r *Object) {
+func (e *Encoder) writeObject(x spec.Encoder, r *Object) error {
 	xo := xml.StartElement{Name: xml.Name{Local: attrObject}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 	}}
@@ -351,7 +465,7 @@ func (e *Encoder) writeObject(x spec.Encoder, r *Object) {
 	if r.Name != "" {
 		xo.Attr = append(xo.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: r.Name})
 	}
-	if r.Mesh != nil {
+	if len(r.Components) == 0 {
 		if r.PID != 0 {
 			xo.Attr = append(xo.Attr, xml.Attr{
 				Name: xml.Name{Local: attrPID}, Value: strconv.FormatUint(uint64(r.PID), 10),
@@ -371,11 +485,17 @@ func (e *Encoder) writeObject(x spec.Encoder, r *Object) {
 	}
 
 	if r.Mesh != nil {
-		e.writeMesh(x, r, r.Mesh)
-	} else {
+		if err := e.writeMesh(x, r, r.Mesh); err != nil {
+			return err
+		}
+	} else if len(r.Components) > 0 {
 		e.writeComponents(x, r.Components)
 	}
+	if err := r.Any.encode(x); err != nil {
+		return err
+	}
 	x.EncodeToken(xo.End())
+	return nil
 }
 
 func (e *Encoder) writeComponents(x spec.Encoder, comps []*Component) {
@@ -398,7 +518,7 @@ func (e *Encoder) writeComponents(x spec.Encoder, comps []*Component) {
 	x.EncodeToken(xcs.End())
 }
 
-func (e *Encoder) writeVertices(x spec.Encoder, m *Mesh) {
+func (e *Encoder) writeVertices(x spec.Encoder, m *Mesh) error {
 	xvs := xml.StartElement{Name: xml.Name{Local: attrVertices}}
 	x.EncodeToken(xvs)
 	prec := x.FloatPresicion()
@@ -410,20 +530,37 @@ func (e *Encoder) writeVertices(x spec.Encoder, m *Mesh) {
 			{Name: xml.Name{Local: attrZ}},
 		},
 	}
-	x.SetAutoClose(true)
-	x.SetSkipAttrEscape(true)
-	for _, v := range m.Vertices {
+	writeVertex := func(v Point3D) {
 		start.Attr[0].Value = strconv.FormatFloat(float64(v.X()), 'f', prec, 32)
 		start.Attr[1].Value = strconv.FormatFloat(float64(v.Y()), 'f', prec, 32)
 		start.Attr[2].Value = strconv.FormatFloat(float64(v.Z()), 'f', prec, 32)
 		x.EncodeToken(start)
 	}
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	if m.Source != nil {
+		for {
+			v, err := m.Source.NextVertex()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			writeVertex(v)
+		}
+	} else {
+		for _, v := range m.Vertices {
+			writeVertex(v)
+		}
+	}
 	x.SetSkipAttrEscape(false)
 	x.SetAutoClose(false)
 	x.EncodeToken(xvs.End())
+	return nil
 }
 
-func (e *Encoder) writeTriangles(x spec.Encoder, r *Object, m *Mesh) {
+func (e *Encoder) writeTriangles(x spec.Encoder, r *Object, m *Mesh) error {
 	xvt := xml.StartElement{Name: xml.Name{Local: attrTriangles}}
 	x.EncodeToken(xvt)
 	start := xml.StartElement{
@@ -438,9 +575,7 @@ func (e *Encoder) writeTriangles(x spec.Encoder, r *Object, m *Mesh) {
 		{Name: xml.Name{Local: attrP2}},
 		{Name: xml.Name{Local: attrP3}},
 	}
-	x.SetAutoClose(true)
-	x.SetSkipAttrEscape(true)
-	for _, v := range m.Triangles {
+	writeTriangle := func(v Triangle) {
 		v1, v2, v3 := v.Indices()
 		attrs[0].Value = strconv.FormatUint(uint64(v1), 10)
 		attrs[1].Value = strconv.FormatUint(uint64(v2), 10)
@@ -463,27 +598,93 @@ func (e *Encoder) writeTriangles(x spec.Encoder, r *Object, m *Mesh) {
 		}
 		x.EncodeToken(start)
 	}
+	x.SetAutoClose(true)
+	x.SetSkipAttrEscape(true)
+	if m.Source != nil {
+		for {
+			v, err := m.Source.NextTriangle()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			writeTriangle(v)
+		}
+	} else {
+		for _, v := range m.Triangles {
+			writeTriangle(v)
+		}
+	}
 	x.SetSkipAttrEscape(false)
 	x.SetAutoClose(false)
 	x.EncodeToken(xvt.End())
+	return nil
 }
 
-func (e *Encoder) writeMesh(x spec.Encoder, r *Object, m *Mesh) {
+func (e *Encoder) writeTriangleSets(x spec.Encoder, m *Mesh) {
+	xts := xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrTriangleSets}}
+	x.EncodeToken(xts)
+	for _, set := range m.TriangleSets {
+		xt := xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrTriangleSet}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: set.Name},
+			{Name: xml.Name{Local: attrIdentifier}, Value: set.Identifier},
+		}}
+		x.EncodeToken(xt)
+		x.SetAutoClose(true)
+		// Consecutive indices are compacted into a single refrange.
+		for i := 0; i < len(set.Refs); {
+			j := i + 1
+			for j < len(set.Refs) && set.Refs[j] == set.Refs[j-1]+1 {
+				j++
+			}
+			if j-i > 1 {
+				x.EncodeToken(xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrRefRange}, Attr: []xml.Attr{
+					{Name: xml.Name{Local: attrStartIndex}, Value: strconv.FormatUint(uint64(set.Refs[i]), 10)},
+					{Name: xml.Name{Local: attrEndIndex}, Value: strconv.FormatUint(uint64(set.Refs[j-1]), 10)},
+				}})
+			} else {
+				x.EncodeToken(xml.StartElement{Name: xml.Name{Space: NamespaceTriangleSets, Local: attrRef}, Attr: []xml.Attr{
+					{Name: xml.Name{Local: attrIndex}, Value: strconv.FormatUint(uint64(set.Refs[i]), 10)},
+				}})
+			}
+			i = j
+		}
+		x.SetAutoClose(false)
+		x.EncodeToken(xt.End())
+	}
+	x.EncodeToken(xts.End())
+}
+
+func (e *Encoder) writeMesh(x spec.Encoder, r *Object, m *Mesh) error {
 	xm := xml.StartElement{Name: xml.Name{Local: attrMesh}}
 	m.AnyAttr.encode(x, &xm)
 	x.EncodeToken(xm)
 
-	e.writeVertices(x, m)
-	e.writeTriangles(x, r, m)
+	if err := e.writeVertices(x, m); err != nil {
+		return err
+	}
+	if err := e.writeTriangles(x, r, m); err != nil {
+		return err
+	}
+	if len(m.TriangleSets) > 0 {
+		e.writeTriangleSets(x, m)
+	}
 
-	m.Any.encode(x)
+	if err := m.Any.encode(x); err != nil {
+		return err
+	}
 	x.EncodeToken(xm.End())
+	return nil
 }
 
 func (r *BaseMaterials) Marshal3MF(x spec.Encoder) error {
 	xt := xml.StartElement{Name: xml.Name{Local: attrBaseMaterials}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 	}}
+	if r.DisplayPropertiesID != 0 {
+		xt.Attr = append(xt.Attr, xml.Attr{Name: xml.Name{Local: attrDisplayPropID}, Value: strconv.FormatUint(uint64(r.DisplayPropertiesID), 10)})
+	}
 	x.EncodeToken(xt)
 	x.SetAutoClose(true)
 	start := xml.StartElement{
@@ -513,7 +714,7 @@ func (e AnyAttr) encode(x spec.Encoder, start *xml.StartElement) {
 
 func (e Any) encode(x spec.Encoder) error {
 	for _, ext := range e {
-		if err := ext.Marshal3MF(x); err == nil {
+		if err := ext.Marshal3MF(x); err != nil {
 			return err
 		}
 	}
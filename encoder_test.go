@@ -1,12 +1,15 @@
 package go3mf
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/xml"
 	"errors"
 	"image/color"
+	"io"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -47,11 +50,11 @@ func TestMarshalModel(t *testing.T) {
 	Register(fakeSpec.Namespace, new(qmExtension))
 	m := &Model{
 		Units: UnitMillimeter, Language: "en-US", Path: "/3D/3dmodel.model", Thumbnail: "/thumbnail.png",
-		Extensions: []Extension{fakeSpec},
+		Extensions: []Extension{fakeSpec, {Namespace: NamespaceTriangleSets, LocalName: "t"}},
 		AnyAttr:    AnyAttr{&fakeAttr{Value: "model_fake"}},
 		Resources: Resources{
 			Assets: []Asset{
-				&BaseMaterials{ID: 5, Materials: []Base{
+				&BaseMaterials{ID: 5, DisplayPropertiesID: 10, Materials: []Base{
 					{Name: "Blue PLA", Color: color.RGBA{0, 0, 255, 255}},
 					{Name: "Red ABS", Color: color.RGBA{255, 0, 0, 255}},
 				}}, &fakeAsset{ID: 25}},
@@ -78,6 +81,10 @@ func TestMarshalModel(t *testing.T) {
 							NewTrianglePID(3, 0, 4, 5, 0, 0, 0),
 							NewTrianglePID(4, 7, 3, 5, 0, 0, 0),
 						},
+						TriangleSets: []TriangleSet{
+							{Name: "Top", Identifier: "top", Refs: []uint32{2, 3, 5}},
+							{Name: "Sides", Identifier: "sides", Refs: []uint32{11, 4, 5, 6, 7}},
+						},
 					}},
 				{
 					ID: 20, Type: ObjectTypeSupport,
@@ -118,6 +125,148 @@ func TestMarshalModel(t *testing.T) {
 	})
 }
 
+func TestMarshalModel_Any(t *testing.T) {
+	m := &Model{
+		Any: Any{&fakeAsset{ID: 10}, &fakeAsset{ID: 11}},
+		Resources: Resources{Objects: []*Object{
+			{ID: 1, PID: 2, Any: Any{&fakeAsset{ID: 20}, &fakeAsset{ID: 21}}},
+		}},
+	}
+	b, err := MarshalModel(m)
+	if err != nil {
+		t.Fatalf("MarshalModel() error = %v", err)
+	}
+	for _, want := range []string{`id="10"`, `id="11"`, `id="20"`, `id="21"`, `pid="2"`} {
+		if !bytes.Contains(b, []byte(want)) {
+			t.Errorf("MarshalModel() = %s, want %s", b, want)
+		}
+	}
+}
+
+type errMarshaler struct{}
+
+func (errMarshaler) Marshal3MF(_ spec.Encoder) error {
+	return errors.New("marshal error")
+}
+
+func TestMarshalModel_AnyError(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Model
+	}{
+		{"model", &Model{Any: Any{errMarshaler{}}}},
+		{"object", &Model{Resources: Resources{Objects: []*Object{{ID: 1, Any: Any{errMarshaler{}}}}}}},
+		{"mesh", &Model{Resources: Resources{Objects: []*Object{{ID: 1, Mesh: &Mesh{
+			Vertices:  []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+			Triangles: []Triangle{NewTriangle(0, 1, 2)},
+			Any:       Any{errMarshaler{}},
+		}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MarshalModel(tt.m); err == nil {
+				t.Error("MarshalModel() expected error")
+			}
+		})
+	}
+}
+
+func TestMarshalModel_TriangleSets(t *testing.T) {
+	sets := []TriangleSet{{Name: "Label", Identifier: "label", Refs: []uint32{0, 2, 3}}}
+	m := &Model{Path: "/3D/3dmodel.model", Resources: Resources{Objects: []*Object{
+		{ID: 1, Mesh: &Mesh{
+			Vertices:     []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+			Triangles:    []Triangle{NewTriangle(0, 1, 2), NewTriangle(0, 3, 1), NewTriangle(0, 2, 3), NewTriangle(1, 3, 2)},
+			TriangleSets: sets,
+		}},
+	}}}
+	b, err := MarshalModel(m)
+	if err != nil {
+		t.Fatalf("MarshalModel() error = %v", err)
+	}
+	newModel := new(Model)
+	newModel.Path = m.Path
+	if err := UnmarshalModel(b, newModel); err != nil {
+		t.Fatalf("MarshalModel() error decoding = %v, s = %s", err, string(b))
+	}
+	if diff := deep.Equal(newModel.Extensions, []Extension{{Namespace: NamespaceTriangleSets, LocalName: "t"}}); diff != nil {
+		t.Errorf("MarshalModel() extensions = %v, s = %s", diff, string(b))
+	}
+	if diff := deep.Equal(newModel.Resources.Objects[0].Mesh.TriangleSets, sets); diff != nil {
+		t.Errorf("MarshalModel() triangle sets = %v, s = %s", diff, string(b))
+	}
+}
+
+type stripSource struct {
+	n, v, t int
+	err     error
+}
+
+func (s *stripSource) NextVertex() (Point3D, error) {
+	if s.v >= s.n*2 {
+		return Point3D{}, io.EOF
+	}
+	i := s.v
+	s.v++
+	return Point3D{float32(i / 2), float32(i % 2), 0}, nil
+}
+
+func (s *stripSource) NextTriangle() (Triangle, error) {
+	if s.err != nil {
+		return Triangle{}, s.err
+	}
+	if s.t >= (s.n-1)*2 {
+		return Triangle{}, io.EOF
+	}
+	i := uint32(s.t)
+	s.t++
+	return NewTrianglePID(i, i+1, i+2, 1, 0, 0, 0), nil
+}
+
+func TestEncoder_Encode_MeshSource(t *testing.T) {
+	want := new(Mesh)
+	src := &stripSource{n: 50}
+	for {
+		v, err := src.NextVertex()
+		if err != nil {
+			break
+		}
+		want.Vertices = append(want.Vertices, v)
+	}
+	for {
+		tr, err := src.NextTriangle()
+		if err != nil {
+			break
+		}
+		want.Triangles = append(want.Triangles, tr)
+	}
+	t.Run("base", func(t *testing.T) {
+		m := &Model{Resources: Resources{Objects: []*Object{
+			{ID: 2, PID: 1, Mesh: &Mesh{Source: &stripSource{n: 50}}},
+		}}}
+		buff := new(bytes.Buffer)
+		if err := NewEncoder(buff).Encode(m); err != nil {
+			t.Fatalf("Encoder.Encode() error = %v", err)
+		}
+		newModel := new(Model)
+		if err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(newModel); err != nil {
+			t.Fatalf("Encoder.Encode() malformed = %v", err)
+		}
+		if diff := deep.Equal(newModel.Resources.Objects[0].Mesh, want); diff != nil {
+			t.Errorf("Encoder.Encode() = %v", diff)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		wantErr := errors.New("source error")
+		m := &Model{Resources: Resources{Objects: []*Object{
+			{ID: 2, Mesh: &Mesh{Source: &stripSource{n: 50, err: wantErr}}},
+		}}}
+		if err := NewEncoder(new(bytes.Buffer)).Encode(m); err != wantErr {
+			t.Errorf("Encoder.Encode() error = %v, want %v", err, wantErr)
+		}
+	})
+}
+
 func TestEncoder_writeAttachements(t *testing.T) {
 	type args struct {
 		m *Model
@@ -141,7 +290,7 @@ func TestEncoder_writeAttachements(t *testing.T) {
 			m := new(mockPackage)
 			mp := new(mockPackagePart)
 			mp.On("Write", mock.Anything).Return(mock.Anything, mock.Anything)
-			m.On("Create", mock.Anything, mock.Anything).Return(mp, argErr)
+			m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(mp, argErr)
 			m.On("AddRelationship", mock.Anything).Return()
 			tt.e.w = m
 			if err := tt.e.writeAttachements(tt.args.m.Attachments); (err != nil) != tt.wantErr {
@@ -151,6 +300,28 @@ func TestEncoder_writeAttachements(t *testing.T) {
 	}
 }
 
+// closeTracker records whether it has been closed.
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestEncoder_Encode_AttachmentStreamNotClosed(t *testing.T) {
+	stream := &closeTracker{Reader: bytes.NewBufferString("fake")}
+	m := &Model{Attachments: []Attachment{{ContentType: "image/png", Path: "/Metadata/thumbnail.png", Stream: stream}}}
+	if err := NewEncoder(new(bytes.Buffer)).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	if stream.closed {
+		t.Error("Encoder.Encode() closed the attachment stream")
+	}
+}
+
 func TestNewEncoder(t *testing.T) {
 	tests := []struct {
 		name string
@@ -221,7 +392,9 @@ func TestEncoder_Encode_Normalize(t *testing.T) {
 				return
 			}
 			newModel := new(Model)
-			err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(newModel)
+			d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+			d.EagerAttachments = true
+			err := d.Decode(newModel)
 			if err != nil {
 				t.Errorf("Encoder.Encode() malformed = %v", err)
 				return
@@ -280,7 +453,9 @@ func TestEncoder_Encode_Roundtrip(t *testing.T) {
 				return
 			}
 			newModel := new(Model)
-			err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(newModel)
+			d := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+			d.EagerAttachments = true
+			err := d.Decode(newModel)
 			if err != nil {
 				t.Errorf("Encoder.Encode() malformed = %v", err)
 				return
@@ -294,3 +469,116 @@ func TestEncoder_Encode_Roundtrip(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoder_compression(t *testing.T) {
+	e := &Encoder{
+		Compression:            CompressionFast,
+		ContentTypeCompression: map[string]Compression{"image/png": CompressionNone, ContentType3DModel: CompressionMaximum},
+		CompressionFunc: func(name, _ string) (Compression, bool) {
+			return CompressionSuperFast, name == "/3D/Textures/raw.png"
+		},
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		want        Compression
+	}{
+		{"/3D/3dmodel.model", ContentType3DModel, CompressionMaximum},
+		{"/Metadata/thumbnail.png", "image/png", CompressionNone},
+		{"/3D/Textures/raw.png", "image/png", CompressionSuperFast},
+		{"/3D/Metadata/pt.xml", "application/vnd.ms-printing.printticket+xml", CompressionFast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.compression(tt.name, tt.contentType); got != tt.want {
+				t.Errorf("Encoder.compression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := new(Encoder).compression("/3D/3dmodel.model", ContentType3DModel); got != CompressionNormal {
+		t.Errorf("Encoder.compression() = %v, want %v", got, CompressionNormal)
+	}
+}
+
+func TestEncoder_Encode_Compression(t *testing.T) {
+	m := &Model{
+		Thumbnail: "/Metadata/thumbnail.png",
+		Attachments: []Attachment{
+			{ContentType: "image/png", Path: "/Metadata/thumbnail.png", Stream: bytes.NewBufferString(strings.Repeat("a", 1000))},
+		},
+	}
+	buff := new(bytes.Buffer)
+	e := NewEncoder(buff)
+	e.ContentTypeCompression = map[string]Compression{"image/png": CompressionNone, ContentType3DModel: CompressionMaximum}
+	if err := e.Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range r.File {
+		switch f.Name {
+		case "Metadata/thumbnail.png":
+			if f.CompressedSize64 < f.UncompressedSize64 {
+				t.Errorf("Encoder.Encode() thumbnail compressed to %d bytes, want stored", f.CompressedSize64)
+			}
+		case "3D/3dmodel.model":
+			if f.Flags&0x6 != 0x2 {
+				t.Errorf("Encoder.Encode() model flags = %x, want maximum compression", f.Flags)
+			}
+		}
+	}
+}
+
+func TestEncoder_Encode_Deterministic(t *testing.T) {
+	newModel := func() *Model {
+		return &Model{
+			Thumbnail: "/Metadata/thumbnail.png",
+			Attachments: []Attachment{
+				{ContentType: "image/png", Path: "/Metadata/thumbnail.png", Stream: bytes.NewBufferString("fake")},
+				{ContentType: "application/vnd.ms-printing.printticket+xml", Path: "/3D/Metadata/pt.xml", Stream: bytes.NewBufferString("other")},
+			},
+			Childs: map[string]*ChildModel{
+				"/a.model": {},
+				"/b.model": {},
+				"/c.model": {Relationships: []Relationship{
+					{Path: "/3D/Metadata/pt.xml", Type: "http://schemas.microsoft.com/3dmanufacturing/2013/01/printticket"}},
+				},
+				"/d.model": {},
+			},
+		}
+	}
+	encode := func(c Compression) []byte {
+		buff := new(bytes.Buffer)
+		e := NewEncoder(buff)
+		e.Deterministic = true
+		e.ContentTypeCompression = map[string]Compression{"image/png": c}
+		if err := e.Encode(newModel()); err != nil {
+			t.Fatalf("Encoder.Encode() error = %v", err)
+		}
+		if _, ok := e.w.(*opcWriter); !ok {
+			t.Fatalf("Encoder.Encode() replaced the package writer with %T", e.w)
+		}
+		return buff.Bytes()
+	}
+	for _, c := range []Compression{CompressionNormal, CompressionNone, CompressionMaximum, CompressionFast} {
+		b := encode(c)
+		for i := 0; i < 5; i++ {
+			if got := encode(c); !bytes.Equal(got, b) {
+				t.Fatalf("Encoder.Encode() is not deterministic with compression %d", c)
+			}
+		}
+		got := new(Model)
+		d := NewDecoder(bytes.NewReader(b), int64(len(b)))
+		d.EagerAttachments = true
+		if err := d.Decode(got); err != nil {
+			t.Fatalf("Decoder.Decode() error = %v", err)
+		}
+		want := newModel().Childs
+		want["/c.model"].Relationships[0].ID = "rId1"
+		if diff := deep.Equal(got.Childs, want); diff != nil {
+			t.Errorf("Encoder.Encode() = %v", diff)
+		}
+	}
+}
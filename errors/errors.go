@@ -33,6 +33,7 @@ var (
 	ErrRecursion              = errors.New("MUST NOT contain recursive references")
 	ErrInvalidObject          = errors.New("MUST contain a mesh or components")
 	ErrMeshConsistency        = errors.New("mesh has non-manifold edges without consistent triangle orientation")
+	ErrDuplicatedTriangleSet  = errors.New("triangle set identifiers MUST be unique within a mesh")
 )
 
 type Level struct {
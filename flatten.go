@@ -0,0 +1,132 @@
+package go3mf
+
+import (
+	"github.com/qmuntal/go3mf/errors"
+)
+
+// FlattenObject returns a mesh with the geometry of the object with the target path and ID,
+// resolving its components recursively, including the ones defined in child models.
+//
+// The composed component transforms are applied to the vertices and the triangle
+// winding is flipped for mirroring transforms. Triangles without properties get
+// the properties of their object. Property IDs are copied as is, so they are only
+// meaningful when all the objects are defined in the same model part.
+// Triangle sets with the same identifier are merged.
+// Mesh extension values and sources are not copied.
+func (m *Model) FlattenObject(path string, id uint32) (*Mesh, error) {
+	obj, ok := m.FindObject(path, id)
+	if !ok {
+		return nil, errors.ErrMissingResource
+	}
+	f := flattener{m: m, mesh: new(Mesh), visiting: make(map[resourceKey]struct{})}
+	if err := f.add(path, obj, Identity()); err != nil {
+		return nil, errors.Wrap(err, obj)
+	}
+	return f.mesh, nil
+}
+
+// FlattenBuild returns one object with a mesh for each build item,
+// in the same order, as returned by FlattenObject with the item transform applied.
+//
+// The returned objects are numbered from 1 and keep the name, part number
+// and type of the object referenced by the item.
+func (m *Model) FlattenBuild() ([]*Object, error) {
+	objs := make([]*Object, len(m.Build.Items))
+	for i, item := range m.Build.Items {
+		obj, ok := m.FindObject(item.ObjectPath(), item.ObjectID)
+		if !ok {
+			return nil, errors.WrapIndex(errors.ErrMissingResource, item, i)
+		}
+		f := flattener{m: m, mesh: new(Mesh), visiting: make(map[resourceKey]struct{})}
+		if err := f.add(item.ObjectPath(), obj, transformOrIdentity(item.Transform)); err != nil {
+			return nil, errors.WrapIndex(errors.Wrap(err, obj), item, i)
+		}
+		objs[i] = &Object{
+			ID:         uint32(i + 1),
+			Name:       obj.Name,
+			PartNumber: obj.PartNumber,
+			Type:       obj.Type,
+			Mesh:       f.mesh,
+		}
+	}
+	return objs, nil
+}
+
+type flattener struct {
+	m        *Model
+	mesh     *Mesh
+	visiting map[resourceKey]struct{}
+}
+
+func (f *flattener) add(path string, obj *Object, transform Matrix) error {
+	key := resourceKey{path, obj.ID}
+	if key.path == f.m.PathOrDefault() {
+		key.path = ""
+	}
+	if _, ok := f.visiting[key]; ok {
+		return errors.ErrRecursion
+	}
+	f.visiting[key] = struct{}{}
+	defer delete(f.visiting, key)
+	if obj.Mesh != nil {
+		f.addMesh(obj, transform)
+	}
+	for i, c := range obj.Components {
+		cpath := c.ObjectPath(path)
+		cobj, ok := f.m.FindObject(cpath, c.ObjectID)
+		if !ok {
+			return errors.WrapIndex(errors.ErrMissingResource, c, i)
+		}
+		if err := f.add(cpath, cobj, transform.Mul(transformOrIdentity(c.Transform))); err != nil {
+			return errors.WrapIndex(err, c, i)
+		}
+	}
+	return nil
+}
+
+func (f *flattener) addMesh(obj *Object, transform Matrix) {
+	mesh := f.mesh
+	vOffset, tOffset := uint32(len(mesh.Vertices)), uint32(len(mesh.Triangles))
+	for _, v := range obj.Mesh.Vertices {
+		mesh.Vertices = append(mesh.Vertices, transform.Mul3D(v))
+	}
+	flip := transform.Determinant() < 0
+	for _, t := range obj.Mesh.Triangles {
+		v1, v2, v3 := t.Indices()
+		pid := t.PID()
+		p1, p2, p3 := t.PIndices()
+		if pid == 0 && obj.PID != 0 {
+			pid = obj.PID
+			p1, p2, p3 = obj.PIndex, obj.PIndex, obj.PIndex
+		}
+		if flip {
+			v2, v3 = v3, v2
+			p2, p3 = p3, p2
+		}
+		mesh.Triangles = append(mesh.Triangles, NewTrianglePID(v1+vOffset, v2+vOffset, v3+vOffset, pid, p1, p2, p3))
+	}
+	for _, ts := range obj.Mesh.TriangleSets {
+		idx := -1
+		for i := range mesh.TriangleSets {
+			if mesh.TriangleSets[i].Identifier == ts.Identifier {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			mesh.TriangleSets = append(mesh.TriangleSets, TriangleSet{Name: ts.Name, Identifier: ts.Identifier})
+			idx = len(mesh.TriangleSets) - 1
+		}
+		for _, ref := range ts.Refs {
+			mesh.TriangleSets[idx].Refs = append(mesh.TriangleSets[idx].Refs, ref+tOffset)
+		}
+	}
+}
+
+// transformOrIdentity returns the identity matrix if t is not defined.
+func transformOrIdentity(t Matrix) Matrix {
+	if t == (Matrix{}) {
+		return Identity()
+	}
+	return t
+}
@@ -0,0 +1,132 @@
+package go3mf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+func TestModel_FlattenObject(t *testing.T) {
+	triangle := func() *Mesh {
+		return &Mesh{
+			Vertices:     []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+			Triangles:    []Triangle{NewTriangle(0, 1, 2)},
+			TriangleSets: []TriangleSet{{Name: "set", Identifier: "s", Refs: []uint32{0}}},
+		}
+	}
+	m := &Model{
+		Resources: Resources{Objects: []*Object{
+			{ID: 1, PID: 5, PIndex: 2, Mesh: triangle()},
+			{ID: 2, Components: []*Component{
+				{ObjectID: 1},
+				{ObjectID: 1, Transform: Identity().Translate(10, 0, 0)},
+			}},
+			{ID: 3, Components: []*Component{
+				{ObjectID: 2, Transform: Identity().Translate(0, 0, 5)},
+				{ObjectID: 4, Transform: Matrix{-1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}},
+			}},
+			{ID: 4, Mesh: &Mesh{
+				Vertices:  []Point3D{{1, 0, 0}, {2, 0, 0}, {1, 1, 0}},
+				Triangles: []Triangle{NewTrianglePID(0, 1, 2, 6, 0, 1, 2)},
+			}},
+			{ID: 5, Components: []*Component{
+				{ObjectID: 1, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/other.model"}}},
+			}},
+			{ID: 6, Components: []*Component{{ObjectID: 1}, {ObjectID: 10}}},
+			{ID: 7, Components: []*Component{{ObjectID: 8}}},
+			{ID: 8, Components: []*Component{{ObjectID: 7}}},
+		}},
+		Childs: map[string]*ChildModel{
+			"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1, Mesh: &Mesh{
+				Vertices:  []Point3D{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}},
+				Triangles: []Triangle{NewTriangle(0, 1, 2)},
+			}}}}},
+		},
+	}
+	tests := []struct {
+		name    string
+		path    string
+		id      uint32
+		want    *Mesh
+		wantErr error
+	}{
+		{"mesh", "", 1, &Mesh{
+			Vertices:     []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+			Triangles:    []Triangle{NewTrianglePID(0, 1, 2, 5, 2, 2, 2)},
+			TriangleSets: []TriangleSet{{Name: "set", Identifier: "s", Refs: []uint32{0}}},
+		}, nil},
+		{"nested", "", 3, &Mesh{
+			Vertices: []Point3D{
+				{0, 0, 5}, {1, 0, 5}, {0, 1, 5},
+				{10, 0, 5}, {11, 0, 5}, {10, 1, 5},
+				{-1, 0, 0}, {-2, 0, 0}, {-1, 1, 0},
+			},
+			Triangles: []Triangle{
+				NewTrianglePID(0, 1, 2, 5, 2, 2, 2),
+				NewTrianglePID(3, 4, 5, 5, 2, 2, 2),
+				NewTrianglePID(6, 8, 7, 6, 0, 2, 1),
+			},
+			TriangleSets: []TriangleSet{{Name: "set", Identifier: "s", Refs: []uint32{0, 1}}},
+		}, nil},
+		{"child", "", 5, &Mesh{
+			Vertices:  []Point3D{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}},
+			Triangles: []Triangle{NewTriangle(0, 1, 2)},
+		}, nil},
+		{"child path", "/3D/other.model", 1, &Mesh{
+			Vertices:  []Point3D{{0, 0, 1}, {1, 0, 1}, {0, 1, 1}},
+			Triangles: []Triangle{NewTriangle(0, 1, 2)},
+		}, nil},
+		{"notfound", "", 100, nil, specerr.ErrMissingResource},
+		{"missing component", "", 6, nil, specerr.ErrMissingResource},
+		{"recursion", "", 7, nil, specerr.ErrRecursion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.FlattenObject(tt.path, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Model.FlattenObject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Errorf("Model.FlattenObject() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestModel_FlattenBuild(t *testing.T) {
+	m := &Model{
+		Resources: Resources{Objects: []*Object{
+			{ID: 4, Name: "part", PartNumber: "p1", Type: ObjectTypeSupport, Mesh: &Mesh{
+				Vertices:  []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+				Triangles: []Triangle{NewTriangle(0, 1, 2)},
+			}},
+		}},
+		Build: Build{Items: []*Item{
+			{ObjectID: 4},
+			{ObjectID: 4, Transform: Identity().Translate(0, 0, 3)},
+		}},
+	}
+	want := []*Object{
+		{ID: 1, Name: "part", PartNumber: "p1", Type: ObjectTypeSupport, Mesh: &Mesh{
+			Vertices:  []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+			Triangles: []Triangle{NewTriangle(0, 1, 2)},
+		}},
+		{ID: 2, Name: "part", PartNumber: "p1", Type: ObjectTypeSupport, Mesh: &Mesh{
+			Vertices:  []Point3D{{0, 0, 3}, {1, 0, 3}, {0, 1, 3}},
+			Triangles: []Triangle{NewTriangle(0, 1, 2)},
+		}},
+	}
+	got, err := m.FlattenBuild()
+	if err != nil {
+		t.Fatalf("Model.FlattenBuild() error = %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Model.FlattenBuild() = %v", diff)
+	}
+	m.Build.Items = append(m.Build.Items, &Item{ObjectID: 10})
+	if _, err := m.FlattenBuild(); !errors.Is(err, specerr.ErrMissingResource) {
+		t.Errorf("Model.FlattenBuild() error = %v, want %v", err, specerr.ErrMissingResource)
+	}
+}
@@ -0,0 +1,144 @@
+//go:build go1.16
+// +build go1.16
+
+package go3mf
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/qmuntal/opc"
+)
+
+// NewFSDecoder returns a new decoder that reads an unzipped package
+// whose root is the root of fsys.
+func NewFSDecoder(fsys fs.FS) *Decoder {
+	return &Decoder{
+		p:      &fsReader{fsys: fsys},
+		Strict: true,
+	}
+}
+
+type fsFile struct {
+	r             *fsReader
+	name          string
+	contentType   string
+	relationships []Relationship
+}
+
+func (f *fsFile) Open() (io.ReadCloser, error) {
+	return f.r.fsys.Open(strings.TrimPrefix(f.name, "/"))
+}
+
+func (f *fsFile) Name() string {
+	return f.name
+}
+
+func (f *fsFile) ContentType() string {
+	return f.contentType
+}
+
+func (f *fsFile) FindFileFromName(name string) (packageFile, bool) {
+	return f.r.findFile(opc.ResolveRelationship(f.name, name))
+}
+
+func (f *fsFile) Relationships() []Relationship {
+	return f.relationships
+}
+
+// fsReader implements a packageReader that reads each part from a file
+// in a fs.FS, parsing the content types and relationships parts itself.
+type fsReader struct {
+	fsys          fs.FS
+	files         map[string]*fsFile // keys are lower case part names.
+	relationships []Relationship
+}
+
+// Open walks the file system and indexes every part.
+// The decompressor is ignored as parts are not compressed.
+func (r *fsReader) Open(_ func(r io.Reader) io.ReadCloser) error {
+	defaults, overrides, err := r.readContentTypes()
+	if err != nil {
+		return err
+	}
+	if r.relationships, err = r.readRelationships(packageRelsName); err != nil {
+		return err
+	}
+	r.files = make(map[string]*fsFile)
+	return fs.WalkDir(r.fsys, ".", func(fpath string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return err
+		}
+		name := "/" + fpath
+		if strings.EqualFold(name, contentTypesName) || isRelsName(name) {
+			return nil
+		}
+		key := strings.ToLower(name)
+		ct, ok := overrides[key]
+		if !ok {
+			ct = defaults[partExtension(name)]
+		}
+		rels, err := r.readRelationships(relsName(name))
+		if err != nil {
+			return err
+		}
+		r.files[key] = &fsFile{r: r, name: name, contentType: ct, relationships: rels}
+		return nil
+	})
+}
+
+func (r *fsReader) Relationships() []Relationship {
+	return r.relationships
+}
+
+func (r *fsReader) FindFileFromName(name string) (packageFile, bool) {
+	return r.findFile(opc.ResolveRelationship("/", name))
+}
+
+func (r *fsReader) findFile(name string) (packageFile, bool) {
+	if f, ok := r.files[strings.ToLower(name)]; ok {
+		return f, true
+	}
+	return nil, false
+}
+
+func (r *fsReader) readContentTypes() (map[string]string, map[string]string, error) {
+	f, err := r.fsys.Open(strings.TrimPrefix(contentTypesName, "/"))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	var x xmlContentTypes
+	if err = xml.NewDecoder(f).Decode(&x); err != nil {
+		return nil, nil, err
+	}
+	defaults := make(map[string]string, len(x.Defaults))
+	for _, d := range x.Defaults {
+		defaults[strings.ToLower(d.Extension)] = d.ContentType
+	}
+	overrides := make(map[string]string, len(x.Overrides))
+	for _, o := range x.Overrides {
+		overrides[strings.ToLower(opc.NormalizePartName(o.PartName))] = o.ContentType
+	}
+	return defaults, overrides, nil
+}
+
+// readRelationships returns nil if the relationships part does not exist.
+func (r *fsReader) readRelationships(name string) ([]Relationship, error) {
+	f, err := r.fsys.Open(strings.TrimPrefix(name, "/"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return decodeRelationships(f)
+}
+
+func isRelsName(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".rels") && strings.Contains(name, "/_rels/")
+}
@@ -0,0 +1,216 @@
+//go:build go1.16
+// +build go1.16
+
+package go3mf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-test/deep"
+)
+
+func TestDirEncoder_FSDecoder_Roundtrip(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *Model
+	}{
+		{"empty", new(Model)},
+		{"withAttrs", &Model{Path: "/a/other.ml", Language: "un", Units: UnitFoot, Thumbnail: "/thumb.png"}},
+		{"withRootRel", &Model{
+			RootRelationships: []Relationship{
+				{Path: "/3D/Metadata/pt.xml", Type: "http://schemas.microsoft.com/3dmanufacturing/2013/01/printticket", ID: "1"},
+				{Path: "/Metadata/thumbnail.png", Type: "http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail", ID: "2"},
+			},
+			Attachments: []Attachment{
+				{ContentType: "application/vnd.ms-printing.printticket+xml", Path: "/3D/Metadata/pt.xml", Stream: bytes.NewBufferString("other")},
+				{ContentType: "image/png", Path: "/Metadata/thumbnail.png", Stream: bytes.NewBufferString("fake")},
+			}},
+		},
+		{"withChildModel", &Model{
+			Attachments: []Attachment{
+				{ContentType: "application/vnd.ms-printing.printticket+xml", Path: "/3D/Metadata/pt.xml", Stream: bytes.NewBufferString("other")},
+			},
+			Childs: map[string]*ChildModel{
+				"/empty.model": {},
+				"/other.model": {Relationships: []Relationship{
+					{Path: "/3D/Metadata/pt.xml", Type: "http://schemas.microsoft.com/3dmanufacturing/2013/01/printticket", ID: "1"}},
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "go3mf")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if err := NewDirEncoder(dir).Encode(tt.m); err != nil {
+				t.Errorf("Encoder.Encode() error = %v", err)
+				return
+			}
+			newModel := new(Model)
+			d := NewFSDecoder(os.DirFS(dir))
+			d.EagerAttachments = true
+			if err := d.Decode(newModel); err != nil {
+				t.Errorf("Decoder.Decode() error = %v", err)
+				return
+			}
+			if tt.m.Path == "" {
+				tt.m.Path = DefaultModelPath
+			}
+			if diff := deep.Equal(newModel, tt.m); diff != nil {
+				t.Errorf("Decoder.Decode() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestDirEncoder_Encode_Deterministic(t *testing.T) {
+	encode := func() string {
+		dir, err := ioutil.TempDir("", "go3mf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := &Model{
+			Attachments: []Attachment{
+				{ContentType: "application/vnd.ms-printing.printticket+xml", Path: "/3D/Metadata/pt.xml", Stream: bytes.NewBufferString("other")},
+			},
+			Childs: map[string]*ChildModel{
+				"/a.model": {},
+				"/b.model": {Relationships: []Relationship{
+					{Path: "/3D/Metadata/pt.xml", Type: "http://schemas.microsoft.com/3dmanufacturing/2013/01/printticket"}},
+				},
+			},
+		}
+		e := NewDirEncoder(dir)
+		e.Deterministic = true
+		if err := e.Encode(m); err != nil {
+			t.Fatalf("Encoder.Encode() error = %v", err)
+		}
+		return dir
+	}
+	want, got := encode(), encode()
+	defer os.RemoveAll(want)
+	defer os.RemoveAll(got)
+	err := filepath.Walk(want, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(want, path)
+		b1, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		b2, err := ioutil.ReadFile(filepath.Join(got, rel))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(b1, b2) {
+			t.Errorf("Encoder.Encode() %s is not deterministic", rel)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDirEncoder_Encode_OutsideDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go3mf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	pkg := filepath.Join(dir, "pkg")
+	w := newDirWriter(pkg)
+	for _, name := range []string{"/../escape.txt", "/a/../../escape.txt"} {
+		if _, err := w.create(name, CompressionNone); err == nil {
+			t.Errorf("fileWriter.create(%s) expected error", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); !os.IsNotExist(err) {
+		t.Errorf("fileWriter.create() wrote outside of the package directory")
+	}
+	f, err := w.create("/3D/3dmodel.model", CompressionNone)
+	if err != nil {
+		t.Fatalf("fileWriter.create() error = %v", err)
+	}
+	f.(io.Closer).Close()
+}
+
+func TestFSDecoder_Decode(t *testing.T) {
+	fsys := fstest.MapFS{
+		"[Content_Types].xml": {Data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+			<Default Extension="RELS" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+			<Default Extension="model" ContentType="application/vnd.ms-package.3dmanufacturing-3dmodel+xml"/>
+			<Override PartName="/3D/Other/Thumb.PNG" ContentType="image/png"/>
+		</Types>`)},
+		"_rels/.rels": {Data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rel0" Target="3D/3dmodel.model" Type="http://schemas.microsoft.com/3dmanufacturing/2013/01/3dmodel"/>
+		</Relationships>`)},
+		"3D/_rels/3dmodel.model.rels": {Data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rel1" Target="Other/thumb.png" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail"/>
+			<Relationship Id="rel2" Target="https://example.com/help" TargetMode="External" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"/>
+		</Relationships>`)},
+		"3D/3dmodel.model": {Data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" unit="millimeter" xml:lang="en-US">
+			<resources>
+				<object id="1" type="model"><mesh><vertices>
+					<vertex x="0" y="0" z="0"/><vertex x="1" y="0" z="0"/><vertex x="0" y="1" z="0"/>
+				</vertices><triangles><triangle v1="0" v2="1" v3="2"/></triangles></mesh></object>
+			</resources>
+			<build><item objectid="1"/></build>
+		</model>`)},
+		"3D/Other/Thumb.PNG": {Data: []byte("fake")},
+	}
+	want := &Model{
+		Path:     "/3D/3dmodel.model",
+		Language: "en-US",
+		Units:    UnitMillimeter,
+		Relationships: []Relationship{
+			{ID: "rel1", Path: "Other/thumb.png", Type: "http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail"},
+		},
+		Attachments: []Attachment{
+			{Path: "/3D/Other/Thumb.PNG", ContentType: "image/png", Stream: bytes.NewBufferString("fake")},
+		},
+		Resources: Resources{Objects: []*Object{{ID: 1, Type: ObjectTypeModel, Mesh: &Mesh{
+			Vertices:  []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}},
+			Triangles: []Triangle{NewTriangle(0, 1, 2)},
+		}}}},
+		Build: Build{Items: []*Item{{ObjectID: 1}}},
+	}
+	got := new(Model)
+	d := NewFSDecoder(fsys)
+	d.EagerAttachments = true
+	if err := d.Decode(got); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Errorf("Decoder.Decode() = %v", diff)
+	}
+}
+
+func TestFSDecoder_Decode_Error(t *testing.T) {
+	if err := NewFSDecoder(fstest.MapFS{}).Decode(new(Model)); err == nil {
+		t.Error("Decoder.Decode() expected error when the content types part is missing")
+	}
+	fsys := fstest.MapFS{
+		"[Content_Types].xml": {Data: []byte(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)},
+	}
+	var serr *xml.SyntaxError
+	if err := NewFSDecoder(fsys).Decode(new(Model)); !errors.As(err, &serr) {
+		t.Errorf("Decoder.Decode() error = %v, want xml.SyntaxError", err)
+	}
+}
@@ -0,0 +1,112 @@
+package go3mf
+
+import (
+	"strings"
+
+	"github.com/qmuntal/go3mf/errors"
+)
+
+// InlineChilds moves the resources of every child model into the root model
+// and removes the child models, so the package only contains the root model part.
+//
+// The moved resources are given new IDs using Resources.UnusedID
+// and every reference to them is rewritten, including extension values that
+// implement spec.IDRemapper or spec.PartIDRemapper. Assets that do not implement
+// spec.IDRemapper keep their ID, so an error is returned and m is not modified
+// if that ID is already in use.
+// Extension values that implement spec.PathRemapper are given an empty path
+// when they reference a child model, so they end up referencing the root model.
+// The child model relationships are moved to the root model
+// and the extension values of the child models are discarded.
+func (m *Model) InlineChilds() error {
+	if len(m.Childs) == 0 {
+		return nil
+	}
+	paths := m.childPaths()
+	used := Resources{
+		Assets:  append([]Asset(nil), m.Resources.Assets...),
+		Objects: append([]*Object(nil), m.Resources.Objects...),
+	}
+	ids := make(map[string]idMapper, len(paths))
+	var err error
+	for _, p := range paths {
+		ids[p], err = newIDMapper(&used, &m.Childs[p].Resources)
+		if err != nil {
+			return errors.WrapPath(err, m.Childs[p].Resources, p)
+		}
+		for _, id := range ids[p] {
+			used.Objects = append(used.Objects, &Object{ID: id})
+		}
+	}
+	remapFor := func(path string) func(uint32) uint32 {
+		if m, ok := ids[path]; ok {
+			return m.remap
+		}
+		return idMapper(nil).noop
+	}
+	// partIDsFor rewrites the references to other parts
+	// made from the part with the given path.
+	partIDsFor := func(path string) func(string, uint32) uint32 {
+		return func(p string, id uint32) uint32 {
+			if p == "" {
+				p = path
+			}
+			return remapFor(p)(id)
+		}
+	}
+	removePaths := func(p string) string {
+		if _, ok := m.Childs[p]; ok {
+			return ""
+		}
+		return p
+	}
+	root := m.PathOrDefault()
+	for _, a := range m.Resources.Assets {
+		remapExtension(a, idMapper(nil).noop, removePaths, partIDsFor(root))
+	}
+	for _, o := range m.Resources.Objects {
+		o.remap(idMapper(nil).noop, removePaths, partIDsFor(root))
+	}
+	for _, item := range m.Build.Items {
+		item.ObjectID = remapFor(item.ObjectPath())(item.ObjectID)
+		item.AnyAttr.remap(idMapper(nil).noop, removePaths, partIDsFor(root))
+	}
+	for _, p := range paths {
+		child := m.Childs[p]
+		remap := remapFor(p)
+		for _, a := range child.Resources.Assets {
+			remapExtension(a, remap, removePaths, partIDsFor(p))
+			m.Resources.Assets = append(m.Resources.Assets, a)
+		}
+		for _, o := range child.Resources.Objects {
+			o.remap(remap, removePaths, partIDsFor(p))
+			m.Resources.Objects = append(m.Resources.Objects, o)
+		}
+		for _, r := range child.Relationships {
+			r.Path = resolveRelationship(p, r.Path)
+			m.Relationships = appendRelationships(m.Relationships, []Relationship{r})
+		}
+	}
+	rels := m.Relationships[:0]
+	for _, r := range m.Relationships {
+		if r.Type == RelType3DModel && m.isChildPath(resolveRelationship(root, r.Path)) {
+			continue
+		}
+		rels = append(rels, r)
+	}
+	if len(rels) == 0 {
+		rels = nil
+	}
+	m.Relationships = rels
+	m.Childs = nil
+	return nil
+}
+
+func (m *Model) isChildPath(p string) bool {
+	for cp := range m.Childs {
+		if strings.EqualFold(cp, p) {
+			return true
+		}
+	}
+	return false
+}
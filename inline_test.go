@@ -0,0 +1,80 @@
+package go3mf
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestModel_InlineChilds(t *testing.T) {
+	m := &Model{
+		Resources: Resources{
+			Assets: []Asset{&BaseMaterials{ID: 1}},
+			Objects: []*Object{
+				{ID: 2, Components: []*Component{
+					{ObjectID: 3, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/a.model"}}},
+					{ObjectID: 2, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/b.model"}}},
+				}},
+			},
+		},
+		Build: Build{Items: []*Item{
+			{ObjectID: 2},
+			{ObjectID: 2, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/a.model"}}},
+		}},
+		Relationships: []Relationship{{Path: "/3D/a.model", Type: RelType3DModel}, {Path: "/Metadata/a.xml", Type: RelTypeMustPreserve}},
+		Childs: map[string]*ChildModel{
+			"/3D/a.model": {
+				Resources: Resources{
+					Assets: []Asset{&fakeGroup{ID: 1, TextureID: 5}, &fakeAsset{ID: 5}},
+					Objects: []*Object{
+						{ID: 2, PID: 1, Mesh: &Mesh{Triangles: []Triangle{NewTrianglePID(0, 1, 2, 1, 0, 0, 0)}}},
+						{ID: 3, Components: []*Component{{ObjectID: 2}}},
+					},
+				},
+				Relationships: []Relationship{{Path: "/3D/Textures/a.png", Type: "texture"}},
+			},
+			"/3D/b.model": {Resources: Resources{Objects: []*Object{{ID: 2, Mesh: &Mesh{}}}}},
+		},
+	}
+	want := &Model{
+		Resources: Resources{
+			Assets: []Asset{&BaseMaterials{ID: 1}, &fakeGroup{ID: 3, TextureID: 5}, &fakeAsset{ID: 5}},
+			Objects: []*Object{
+				{ID: 2, Components: []*Component{
+					{ObjectID: 6, AnyAttr: AnyAttr{&fakeAttr{}}},
+					{ObjectID: 7, AnyAttr: AnyAttr{&fakeAttr{}}},
+				}},
+				{ID: 4, PID: 3, Mesh: &Mesh{Triangles: []Triangle{NewTrianglePID(0, 1, 2, 3, 0, 0, 0)}}},
+				{ID: 6, Components: []*Component{{ObjectID: 4}}},
+				{ID: 7, Mesh: &Mesh{}},
+			},
+		},
+		Build: Build{Items: []*Item{
+			{ObjectID: 2},
+			{ObjectID: 4, AnyAttr: AnyAttr{&fakeAttr{}}},
+		}},
+		Relationships: []Relationship{{Path: "/Metadata/a.xml", Type: RelTypeMustPreserve}, {Path: "/3D/Textures/a.png", Type: "texture"}},
+	}
+	if err := m.InlineChilds(); err != nil {
+		t.Fatalf("Model.InlineChilds() error = %v", err)
+	}
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Model.InlineChilds() = %v", diff)
+	}
+}
+
+func TestModel_InlineChilds_FixedIDCollision(t *testing.T) {
+	m := &Model{
+		Resources: Resources{Objects: []*Object{{ID: 5, Mesh: &Mesh{}}}},
+		Childs: map[string]*ChildModel{
+			"/3D/a.model": {Resources: Resources{Assets: []Asset{&fakeAsset{ID: 5}}}},
+		},
+	}
+	want := m.Clone()
+	if err := m.InlineChilds(); err == nil {
+		t.Fatal("Model.InlineChilds() expected error")
+	}
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Model.InlineChilds() modified the model = %v", diff)
+	}
+}
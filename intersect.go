@@ -0,0 +1,355 @@
+package go3mf
+
+import (
+	"math"
+	"sort"
+)
+
+// SelfIntersections returns the pairs of triangles of the mesh that intersect each other,
+// sorted by the first and then by the second triangle index, which is always the greater one.
+//
+// Triangles that share vertices are only reported if they intersect apart from them,
+// which for triangles sharing an edge only happens when they are coplanar and overlap.
+// Degenerate triangles are ignored.
+func (m *Mesh) SelfIntersections() [][2]uint32 {
+	var pairs [][2]uint32
+	b := NewBVH(m)
+	for _, i := range b.order {
+		ti, ok := m.triangle(i)
+		if !ok {
+			continue
+		}
+		b.overlapping(b.boxes[i], func(j uint32) {
+			if j <= i {
+				return
+			}
+			tj, ok := m.triangle(j)
+			if ok && trianglesIntersect(m.Triangles[i], m.Triangles[j], ti, tj) {
+				pairs = append(pairs, [2]uint32{i, j})
+			}
+		})
+	}
+	sortPairs(pairs)
+	return pairs
+}
+
+// ItemIntersection defines two intersecting triangles of two different build items.
+// Items are the build item indices and Triangles the triangle indices
+// of the meshes returned by Model.FlattenBuild for each of them.
+type ItemIntersection struct {
+	Items     [2]int
+	Triangles [2]uint32
+}
+
+// BuildIntersections returns the intersecting triangles of every pair of build items,
+// after applying the item and component transforms, so overlapping parts can be detected.
+// Intersections within the same item are not reported, see Mesh.SelfIntersections.
+// See FlattenBuild for the errors that can be returned.
+func (m *Model) BuildIntersections() ([]ItemIntersection, error) {
+	objs, err := m.FlattenBuild()
+	if err != nil {
+		return nil, err
+	}
+	bvhs := make([]*BVH, len(objs))
+	for i, o := range objs {
+		bvhs[i] = NewBVH(o.Mesh)
+	}
+	var inters []ItemIntersection
+	for i := range objs {
+		for j := i + 1; j < len(objs); j++ {
+			if len(bvhs[i].nodes) == 0 || len(bvhs[j].nodes) == 0 || !bvhs[i].nodes[0].box.overlaps(bvhs[j].nodes[0].box) {
+				continue
+			}
+			mi, mj := objs[i].Mesh, objs[j].Mesh
+			var pairs [][2]uint32
+			for _, ta := range bvhs[i].order {
+				a, ok := mi.triangle(ta)
+				if !ok {
+					continue
+				}
+				bvhs[j].overlapping(bvhs[i].boxes[ta], func(tb uint32) {
+					b, ok := mj.triangle(tb)
+					if ok && triTriIntersect(a, b) {
+						pairs = append(pairs, [2]uint32{ta, tb})
+					}
+				})
+			}
+			sortPairs(pairs)
+			for _, p := range pairs {
+				inters = append(inters, ItemIntersection{Items: [2]int{i, j}, Triangles: p})
+			}
+		}
+	}
+	return inters, nil
+}
+
+func sortPairs(pairs [][2]uint32) {
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+}
+
+type triangle3 [3][3]float64
+
+// triangle returns the vertices of the triangle with index i,
+// or false if it references undefined vertices or is degenerate.
+func (m *Mesh) triangle(i uint32) (triangle3, bool) {
+	v1, v2, v3 := m.Triangles[i].Indices()
+	n := uint32(len(m.Vertices))
+	if v1 >= n || v2 >= n || v3 >= n || v1 == v2 || v2 == v3 || v1 == v3 {
+		return triangle3{}, false
+	}
+	t := triangle3{toFloat64(m.Vertices[v1]), toFloat64(m.Vertices[v2]), toFloat64(m.Vertices[v3])}
+	if t.normal() == ([3]float64{}) {
+		return triangle3{}, false
+	}
+	return t, true
+}
+
+func (t *triangle3) normal() [3]float64 {
+	return cross64(sub64(t[1], t[0]), sub64(t[2], t[0]))
+}
+
+// trianglesIntersect tests two triangles of the same mesh,
+// taking into account the vertices they share.
+func trianglesIntersect(ta, tb Triangle, a, b triangle3) bool {
+	var shared [][2]int
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if ta[i] == tb[j] {
+				shared = append(shared, [2]int{i, j})
+			}
+		}
+	}
+	if len(shared) == 0 {
+		return triTriIntersect(a, b)
+	}
+	if len(shared) > 2 {
+		return false
+	}
+	i, j := shared[0][0], shared[0][1]
+	eps := tolerance(a, b)
+	if n := a.normal(); coplanar(n, a, b, eps) {
+		// Coplanar triangles overlap apart from the shared vertices
+		// if their angles at one of them overlap.
+		v := a[i]
+		return anglesOverlap(n, sub64(a[(i+1)%3], v), sub64(a[(i+2)%3], v), sub64(b[(j+1)%3], v), sub64(b[(j+2)%3], v))
+	}
+	if len(shared) == 2 {
+		return false
+	}
+	// The triangles intersect apart from the shared vertex
+	// if the edge opposite to it crosses the other triangle.
+	return segmentTriangleIntersect(a[(i+1)%3], a[(i+2)%3], b, eps) ||
+		segmentTriangleIntersect(b[(j+1)%3], b[(j+2)%3], a, eps)
+}
+
+// tolerance returns the distance under which two points of the triangles
+// are considered to be the same. Vertices are stored as float32,
+// so it is relative to the magnitude of their coordinates.
+func tolerance(a, b triangle3) float64 {
+	var scale float64
+	for _, t := range [2]triangle3{a, b} {
+		for _, v := range t {
+			for _, c := range v {
+				scale = math.Max(scale, math.Abs(c))
+			}
+		}
+	}
+	return 1e-6 * scale
+}
+
+// snap returns zero if v is within tol of it.
+func snap(v, tol float64) float64 {
+	if math.Abs(v) <= tol {
+		return 0
+	}
+	return v
+}
+
+// coplanar reports whether b lies on the plane of a, which has normal n.
+func coplanar(n [3]float64, a, b triangle3, eps float64) bool {
+	tol := eps * norm64(n)
+	for i := range b {
+		if snap(dot64(n, sub64(b[i], a[0])), tol) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// anglesOverlap reports whether the angle spanned by the directions a1 and a2
+// and the one spanned by b1 and b2, both on the plane with normal n, overlap.
+func anglesOverlap(n, a1, a2, b1, b2 [3]float64) bool {
+	// sin returns the sine of the angle from d to e, zero if they are almost parallel.
+	sin := func(d, e [3]float64) float64 {
+		return snap(dot64(n, cross64(d, e))/(norm64(n)*norm64(d)*norm64(e)), 1e-6)
+	}
+	inside := func(d, e1, e2 [3]float64) bool {
+		o := sin(e1, e2)
+		return sin(e1, d)*o > 0 && sin(d, e2)*o > 0
+	}
+	same := func(d, e [3]float64) bool {
+		return sin(d, e) == 0 && dot64(d, e) > 0
+	}
+	return inside(b1, a1, a2) || inside(b2, a1, a2) || inside(a1, b1, b2) || inside(a2, b1, b2) ||
+		(same(a1, b1) && same(a2, b2)) || (same(a1, b2) && same(a2, b1))
+}
+
+// segmentTriangleIntersect reports whether the segment pq crosses the triangle t.
+func segmentTriangleIntersect(p, q [3]float64, t triangle3, eps float64) bool {
+	n := t.normal()
+	tol := eps * norm64(n)
+	dp, dq := snap(dot64(n, sub64(p, t[0])), tol), snap(dot64(n, sub64(q, t[0])), tol)
+	if (dp > 0 && dq > 0) || (dp < 0 && dq < 0) || dp == dq {
+		return false
+	}
+	s := dp / (dp - dq)
+	x := [3]float64{p[0] + (q[0]-p[0])*s, p[1] + (q[1]-p[1])*s, p[2] + (q[2]-p[2])*s}
+	for i := 0; i < 3; i++ {
+		e := sub64(t[(i+1)%3], t[i])
+		if dot64(n, cross64(e, sub64(x, t[i]))) < -tol*norm64(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// triTriIntersect implements the interval overlap test by Tomas Möller.
+func triTriIntersect(a, b triangle3) bool {
+	eps := tolerance(a, b)
+	n2 := b.normal()
+	tol := eps * norm64(n2)
+	da := [3]float64{}
+	for i := range da {
+		da[i] = snap(dot64(n2, sub64(a[i], b[0])), tol)
+	}
+	if sameSide(da) {
+		return false
+	}
+	n1 := a.normal()
+	tol = eps * norm64(n1)
+	db := [3]float64{}
+	for i := range db {
+		db[i] = snap(dot64(n1, sub64(b[i], a[0])), tol)
+	}
+	if sameSide(db) {
+		return false
+	}
+	if da == ([3]float64{}) {
+		return coplanarIntersect(n1, a, b, eps)
+	}
+	// Project onto the largest axis of the intersection line direction.
+	d := cross64(n1, n2)
+	axis := largestAxis(d)
+	pa := [3]float64{a[0][axis], a[1][axis], a[2][axis]}
+	pb := [3]float64{b[0][axis], b[1][axis], b[2][axis]}
+	a0, a1, ok := interval(pa, da)
+	if !ok {
+		return coplanarIntersect(n1, a, b, eps)
+	}
+	b0, b1, ok := interval(pb, db)
+	if !ok {
+		return coplanarIntersect(n1, a, b, eps)
+	}
+	return a0 <= b1+eps && b0 <= a1+eps
+}
+
+func sameSide(d [3]float64) bool {
+	return (d[0] > 0 && d[1] > 0 && d[2] > 0) || (d[0] < 0 && d[1] < 0 && d[2] < 0)
+}
+
+func largestAxis(v [3]float64) int {
+	axis := 0
+	for i := 1; i < 3; i++ {
+		if math.Abs(v[i]) > math.Abs(v[axis]) {
+			axis = i
+		}
+	}
+	return axis
+}
+
+// interval returns the sorted interval of the intersection line
+// covered by the triangle with projections p and plane distances d.
+func interval(p, d [3]float64) (float64, float64, bool) {
+	var k int
+	switch {
+	case d[0]*d[1] > 0:
+		k = 2
+	case d[0]*d[2] > 0:
+		k = 1
+	case d[1]*d[2] > 0 || d[0] != 0:
+		k = 0
+	case d[1] != 0:
+		k = 1
+	case d[2] != 0:
+		k = 2
+	default:
+		return 0, 0, false
+	}
+	i, j := (k+1)%3, (k+2)%3
+	t1 := p[k] + (p[i]-p[k])*d[k]/(d[k]-d[i])
+	t2 := p[k] + (p[j]-p[k])*d[k]/(d[k]-d[j])
+	if t1 > t2 {
+		t1, t2 = t2, t1
+	}
+	return t1, t2, true
+}
+
+// coplanarIntersect tests two coplanar triangles
+// by projecting them onto the plane that maximizes their area.
+func coplanarIntersect(n [3]float64, a, b triangle3, eps float64) bool {
+	axis := largestAxis(n)
+	x, y := (axis+1)%3, (axis+2)%3
+	var pa, pb [3][2]float64
+	for i := 0; i < 3; i++ {
+		pa[i] = [2]float64{a[i][x], a[i][y]}
+		pb[i] = [2]float64{b[i][x], b[i][y]}
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if segmentsIntersect2D(pa[i], pa[(i+1)%3], pb[j], pb[(j+1)%3], eps) {
+				return true
+			}
+		}
+	}
+	return pointInTriangle2D(pa[0], pb, eps) || pointInTriangle2D(pb[0], pa, eps)
+}
+
+func orient2D(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// length2D returns the length of the segment ab.
+func length2D(a, b [2]float64) float64 {
+	return math.Hypot(b[0]-a[0], b[1]-a[1])
+}
+
+// segmentsIntersect2D reports whether the segments p1p2 and q1q2 intersect,
+// considering the points closer than eps to a segment as lying on it.
+func segmentsIntersect2D(p1, p2, q1, q2 [2]float64, eps float64) bool {
+	tq, tp := eps*length2D(q1, q2), eps*length2D(p1, p2)
+	d1, d2 := snap(orient2D(q1, q2, p1), tq), snap(orient2D(q1, q2, p2), tq)
+	d3, d4 := snap(orient2D(p1, p2, q1), tp), snap(orient2D(p1, p2, q2), tp)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	onSegment := func(a, b, c [2]float64) bool {
+		return math.Min(a[0], b[0])-eps <= c[0] && c[0] <= math.Max(a[0], b[0])+eps &&
+			math.Min(a[1], b[1])-eps <= c[1] && c[1] <= math.Max(a[1], b[1])+eps
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) || (d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) || (d4 == 0 && onSegment(p1, p2, q2))
+}
+
+func pointInTriangle2D(p [2]float64, t [3][2]float64, eps float64) bool {
+	d1 := snap(orient2D(t[0], t[1], p), eps*length2D(t[0], t[1]))
+	d2 := snap(orient2D(t[1], t[2], p), eps*length2D(t[1], t[2]))
+	d3 := snap(orient2D(t[2], t[0], p), eps*length2D(t[2], t[0]))
+	hasNeg := d1 < 0 || d2 < 0 || d3 < 0
+	hasPos := d1 > 0 || d2 > 0 || d3 > 0
+	return !(hasNeg && hasPos)
+}
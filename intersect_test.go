@@ -0,0 +1,92 @@
+package go3mf
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestMesh_SelfIntersections(t *testing.T) {
+	m := &Mesh{
+		Vertices: []Point3D{
+			{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, // 0: base
+			{0.5, 0.5, -1}, {0.5, 0.5, 1}, {0.5, -1, 0}, // 3: crossing
+			{10, 10, 10}, {11, 10, 10}, {10, 11, 10}, // 6: far
+			{1, 0.2, 1}, {1, 0.2, -1}, // 9: crossing with a shared vertex
+			{-1, 0, 1}, {-1, -1, 1}, // 11: touching the shared vertex
+			{0.5, 0.5, 0}, {3, 0.5, 0}, {0.5, 3, 0}, // 13: coplanar
+			{2, 1, 0}, {1, 2, 0}, // 16: coplanar with a shared vertex
+			{1, 1, 0},  // 18: coplanar with a shared edge
+			{-1, 1, 0}, // 19: coplanar neighbour
+		},
+		Triangles: []Triangle{
+			NewTriangle(0, 1, 2),
+			NewTriangle(3, 4, 5),
+			NewTriangle(6, 7, 8),
+			NewTriangle(0, 9, 10),
+			NewTriangle(0, 11, 12),
+			NewTriangle(13, 14, 15),
+			NewTriangle(0, 0, 1),
+			NewTriangle(0, 1, 100),
+			NewTriangle(0, 16, 17),
+			NewTriangle(0, 1, 18),
+			NewTriangle(0, 2, 19),
+		},
+	}
+	want := [][2]uint32{
+		{0, 1}, {0, 3}, {0, 5}, {0, 8}, {0, 9}, {1, 3}, {1, 5}, {1, 8}, {1, 9},
+		{3, 9}, {5, 8}, {5, 9}, {8, 9},
+	}
+	if diff := deep.Equal(m.SelfIntersections(), want); diff != nil {
+		t.Errorf("Mesh.SelfIntersections() = %v", diff)
+	}
+	// Rounding the transformed vertices to float32 moves them slightly off their planes.
+	rotated := &Mesh{Triangles: m.Triangles}
+	rot := RotationEuler(0.3, 0.5, 0.7).Translate(100, -50, 25)
+	for _, v := range m.Vertices {
+		rotated.Vertices = append(rotated.Vertices, rot.Mul3D(v))
+	}
+	if diff := deep.Equal(rotated.SelfIntersections(), want); diff != nil {
+		t.Errorf("Mesh.SelfIntersections() rotated = %v", diff)
+	}
+	if got := newCube(Point3D{}, 1).SelfIntersections(); len(got) != 0 {
+		t.Errorf("Mesh.SelfIntersections() = %v, want none", got)
+	}
+	merged := newCube(Point3D{}, 1)
+	other := newCube(Point3D{0.5, 0.5, 0.5}, 1)
+	for _, tr := range other.Triangles {
+		v1, v2, v3 := tr.Indices()
+		merged.Triangles = append(merged.Triangles, NewTriangle(v1+8, v2+8, v3+8))
+	}
+	merged.Vertices = append(merged.Vertices, other.Vertices...)
+	if got := merged.SelfIntersections(); len(got) == 0 {
+		t.Error("Mesh.SelfIntersections() = none, want intersections")
+	}
+}
+
+func TestModel_BuildIntersections(t *testing.T) {
+	m := &Model{
+		Resources: Resources{Objects: []*Object{{ID: 1, Mesh: newCube(Point3D{}, 1)}}},
+		Build: Build{Items: []*Item{
+			{ObjectID: 1},
+			{ObjectID: 1, Transform: Identity().Translate(2, 0, 0)},
+			{ObjectID: 1, Transform: Identity().Translate(2.5, 0.5, 0.5)},
+		}},
+	}
+	got, err := m.BuildIntersections()
+	if err != nil {
+		t.Fatalf("Model.BuildIntersections() error = %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("Model.BuildIntersections() = none, want intersections")
+	}
+	for _, inter := range got {
+		if inter.Items != [2]int{1, 2} {
+			t.Errorf("Model.BuildIntersections() = %v, want items 1 and 2", inter)
+		}
+	}
+	m.Build.Items = append(m.Build.Items, &Item{ObjectID: 2})
+	if _, err := m.BuildIntersections(); err == nil {
+		t.Error("Model.BuildIntersections() expected error")
+	}
+}
@@ -0,0 +1,125 @@
+package go3mf
+
+import (
+	"io"
+)
+
+// A Decrypter decrypts the package parts protected
+// by the Secure Content extension.
+type Decrypter interface {
+	// ReadKeyStore is called with the content of the package key store
+	// before any other part is read.
+	ReadKeyStore(r io.Reader) error
+	// Decrypt returns a reader with the plain content of the named part.
+	// Parts that are not encrypted must be returned unchanged.
+	Decrypt(name string, r io.ReadCloser) (io.ReadCloser, error)
+}
+
+// An Encrypter encrypts the package parts protected
+// by the Secure Content extension.
+type Encrypter interface {
+	// Encrypt returns a writer that encrypts the content written to the named part.
+	// The content must be flushed to w when the writer is closed.
+	// Parts that must not be encrypted should return a nil writer.
+	Encrypt(name string, w io.Writer) (io.WriteCloser, error)
+	// WriteKeyStore is called once all the other parts have been written.
+	WriteKeyStore(w io.Writer) error
+}
+
+type decryptReader struct {
+	packageReader
+	d Decrypter
+}
+
+func (r *decryptReader) FindFileFromName(name string) (packageFile, bool) {
+	f, ok := r.packageReader.FindFileFromName(name)
+	if ok {
+		f = &decryptFile{packageFile: f, d: r.d}
+	}
+	return f, ok
+}
+
+type decryptFile struct {
+	packageFile
+	d Decrypter
+}
+
+func (f *decryptFile) FindFileFromName(name string) (packageFile, bool) {
+	other, ok := f.packageFile.FindFileFromName(name)
+	if ok {
+		other = &decryptFile{packageFile: other, d: f.d}
+	}
+	return other, ok
+}
+
+func (f *decryptFile) Open() (io.ReadCloser, error) {
+	r, err := f.packageFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	return f.d.Decrypt(f.Name(), r)
+}
+
+type encryptWriter struct {
+	packageWriter
+	e           Encrypter
+	compression func(name, contentType string) Compression
+	encrypted   []string
+	last        io.Closer
+}
+
+func (w *encryptWriter) Create(name, contentType string, c Compression) (packagePart, error) {
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+	p, err := w.packageWriter.Create(name, contentType, c)
+	if err != nil {
+		return nil, err
+	}
+	wc, err := w.e.Encrypt(name, p)
+	if err != nil {
+		return nil, err
+	}
+	if wc == nil {
+		return p, nil
+	}
+	w.encrypted = append(w.encrypted, name)
+	w.last = wc
+	return &encryptPart{WriteCloser: wc, packagePart: p}, nil
+}
+
+func (w *encryptWriter) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	p, err := w.packageWriter.Create(DefaultKeyStorePath, ContentTypeKeyStore, w.compression(DefaultKeyStorePath, ContentTypeKeyStore))
+	if err != nil {
+		return err
+	}
+	if err = w.e.WriteKeyStore(p); err != nil {
+		return err
+	}
+	for _, name := range w.encrypted {
+		p.AddRelationship(Relationship{Type: RelTypeEncryptedFile, Path: name})
+	}
+	w.packageWriter.AddRelationship(Relationship{Type: RelTypeKeyStore, Path: DefaultKeyStorePath})
+	return w.packageWriter.Close()
+}
+
+func (w *encryptWriter) flush() error {
+	if w.last == nil {
+		return nil
+	}
+	err := w.last.Close()
+	w.last = nil
+	return err
+}
+
+type encryptPart struct {
+	io.WriteCloser
+	packagePart
+}
+
+func (p *encryptPart) Write(b []byte) (int, error) {
+	return p.WriteCloser.Write(b)
+}
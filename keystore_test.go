@@ -0,0 +1,172 @@
+package go3mf
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+// reverseCrypter "encrypts" the listed parts by reversing their content.
+type reverseCrypter struct {
+	parts    map[string]bool
+	keyStore string
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, c := range b {
+		r[len(b)-1-i] = c
+	}
+	return r
+}
+
+func (c *reverseCrypter) ReadKeyStore(r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	c.keyStore = string(b)
+	return err
+}
+
+func (c *reverseCrypter) Decrypt(name string, r io.ReadCloser) (io.ReadCloser, error) {
+	if !c.parts[name] {
+		return r, nil
+	}
+	b, err := ioutil.ReadAll(r)
+	r.Close()
+	return ioutil.NopCloser(bytes.NewReader(reverse(b))), err
+}
+
+type reverseWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (w *reverseWriter) Write(b []byte) (int, error) { return w.buf.Write(b) }
+func (w *reverseWriter) Close() error {
+	_, err := w.w.Write(reverse(w.buf.Bytes()))
+	return err
+}
+
+func (c *reverseCrypter) Encrypt(name string, w io.Writer) (io.WriteCloser, error) {
+	if !c.parts[name] {
+		return nil, nil
+	}
+	return &reverseWriter{w: w}, nil
+}
+
+func (c *reverseCrypter) WriteKeyStore(w io.Writer) error {
+	_, err := io.WriteString(w, c.keyStore)
+	return err
+}
+
+func TestEncoder_Encode_Encrypted(t *testing.T) {
+	m := &Model{
+		Path: DefaultModelPath,
+		Attachments: []Attachment{
+			{ContentType: "image/png", Path: "/3D/Textures/a.png", Stream: bytes.NewBufferString("texture")},
+			{ContentType: "image/png", Path: "/3D/Textures/b.png", Stream: bytes.NewBufferString("plain")},
+		},
+		Relationships: []Relationship{
+			{Path: "/3D/Textures/a.png", Type: "texture", ID: "1"},
+			{Path: "/3D/Textures/b.png", Type: "texture", ID: "2"},
+		},
+		Childs: map[string]*ChildModel{
+			"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1, Components: []*Component{{ObjectID: 2}}}}}},
+		},
+	}
+	parts := map[string]bool{"/3D/other.model": true, "/3D/Textures/a.png": true}
+	buff := new(bytes.Buffer)
+	enc := NewEncoder(buff)
+	keyStore := strings.Repeat("keystore", 64)
+	enc.Encrypter = &reverseCrypter{parts: parts, keyStore: keyStore}
+	enc.ContentTypeCompression = map[string]Compression{ContentTypeKeyStore: CompressionNone}
+	if err := enc.Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	if _, ok := enc.w.(*encryptWriter); ok {
+		t.Error("Encoder.Encode() kept the encrypt writer")
+	}
+
+	t.Run("keystore", func(t *testing.T) {
+		p := &opcReader{ra: bytes.NewReader(buff.Bytes()), size: int64(buff.Len())}
+		if err := p.Open(nil); err != nil {
+			t.Fatalf("opcReader.Open() error = %v", err)
+		}
+		f, ok := p.FindFileFromName(DefaultKeyStorePath)
+		if !ok {
+			t.Fatal("Encoder.Encode() did not write the key store")
+		}
+		var got []string
+		for _, r := range f.Relationships() {
+			if r.Type == RelTypeEncryptedFile {
+				got = append(got, r.Path)
+			}
+		}
+		want := []string{"/3D/Textures/a.png", "/3D/other.model"}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("Encoder.Encode() encrypted file relationships = %v", diff)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+		if err != nil {
+			t.Fatalf("zip.NewReader() error = %v", err)
+		}
+		for _, zf := range zr.File {
+			if "/"+zf.Name == DefaultKeyStorePath && zf.CompressedSize64 < zf.UncompressedSize64 {
+				t.Errorf("Encoder.Encode() key store compressed to %d bytes, want stored", zf.CompressedSize64)
+			}
+		}
+	})
+
+	t.Run("decrypted", func(t *testing.T) {
+		crypter := &reverseCrypter{parts: parts}
+		dec := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+		dec.Decrypter = crypter
+		dec.EagerAttachments = true
+		got := new(Model)
+		if err := dec.Decode(got); err != nil {
+			t.Fatalf("Decoder.Decode() error = %v", err)
+		}
+		if crypter.keyStore != keyStore {
+			t.Errorf("Decoder.Decode() keystore = %v, want %v", crypter.keyStore, keyStore)
+		}
+		m.Attachments[0].Stream = bytes.NewBufferString("texture")
+		m.Attachments[1].Stream = bytes.NewBufferString("plain")
+		if diff := deep.Equal(got, m); diff != nil {
+			t.Errorf("Decoder.Decode() = %v", diff)
+		}
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		dec := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+		if err := dec.Decode(new(Model)); err == nil {
+			t.Error("Decoder.Decode() expected error decoding encrypted model")
+		}
+	})
+}
+
+func Test_isModelRelationship(t *testing.T) {
+	encryptedModel := new(mockFile)
+	encryptedModel.On("ContentType").Return(ContentType3DModel)
+	tests := []struct {
+		name string
+		rel  Relationship
+		file *mockFile
+		want bool
+	}{
+		{"model", Relationship{Type: RelType3DModel}, newMockFile("/a.model", nil, nil, false), true},
+		{"other", Relationship{Type: RelTypeThumbnail}, newMockFile("/a.png", nil, nil, false), false},
+		{"encryptedModel", Relationship{Type: RelTypeEncryptedFile}, encryptedModel, true},
+		{"encryptedOther", Relationship{Type: RelTypeEncryptedFile}, newMockFile("/a.png", nil, nil, false), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isModelRelationship(tt.rel, tt.file); got != tt.want {
+				t.Errorf("isModelRelationship() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
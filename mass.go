@@ -0,0 +1,133 @@
+package go3mf
+
+import "math"
+
+// MassProperties contains the geometric properties of a closed mesh
+// assuming a uniform density of 1, so the mass is equal to the volume.
+// The values are expressed in the model units.
+//
+// Volume is negative if the triangles are oriented inwards.
+// Inertia is the inertia tensor relative to the centroid,
+// where Inertia[r][c] is the element in the r'th row and c'th column.
+type MassProperties struct {
+	Volume   float64
+	Area     float64
+	Centroid Point3D
+	Inertia  [3][3]float64
+}
+
+// MassProperties returns the volume, surface area, centroid and inertia tensor of the mesh.
+// The mesh must be closed for the volume, centroid and inertia to be meaningful.
+func (m *Mesh) MassProperties() MassProperties {
+	var mi massIntegrals
+	mi.addMesh(m)
+	return mi.properties()
+}
+
+// ObjectMassProperties returns the mass properties of the object
+// with the target path and ID, including its components with their transforms applied.
+// See FlattenObject for the errors that can be returned.
+func (m *Model) ObjectMassProperties(path string, id uint32) (MassProperties, error) {
+	mesh, err := m.FlattenObject(path, id)
+	if err != nil {
+		return MassProperties{}, err
+	}
+	return mesh.MassProperties(), nil
+}
+
+// BuildMassProperties returns the combined mass properties
+// of all the build items, with their transforms applied.
+// See FlattenBuild for the errors that can be returned.
+func (m *Model) BuildMassProperties() (MassProperties, error) {
+	objs, err := m.FlattenBuild()
+	if err != nil {
+		return MassProperties{}, err
+	}
+	var mi massIntegrals
+	for _, o := range objs {
+		mi.addMesh(o.Mesh)
+	}
+	return mi.properties(), nil
+}
+
+// massIntegrals accumulates the volume integrals of the tetrahedrons
+// formed by each triangle and the origin, following the divergence theorem.
+type massIntegrals struct {
+	volume float64
+	area   float64
+	first  [3]float64    // integral of x, y and z.
+	second [3][3]float64 // integral of the products of x, y and z.
+}
+
+func (mi *massIntegrals) addMesh(m *Mesh) {
+	for _, t := range m.Triangles {
+		i1, i2, i3 := t.Indices()
+		if int(i1) >= len(m.Vertices) || int(i2) >= len(m.Vertices) || int(i3) >= len(m.Vertices) {
+			continue
+		}
+		mi.addTriangle(toFloat64(m.Vertices[i1]), toFloat64(m.Vertices[i2]), toFloat64(m.Vertices[i3]))
+	}
+}
+
+func (mi *massIntegrals) addTriangle(a, b, c [3]float64) {
+	mi.area += norm64(cross64(sub64(b, a), sub64(c, a))) / 2
+	d := dot64(a, cross64(b, c))
+	mi.volume += d / 6
+	var sum [3]float64
+	for i := range sum {
+		sum[i] = a[i] + b[i] + c[i]
+		mi.first[i] += d / 24 * sum[i]
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			mi.second[i][j] += d / 120 * (a[i]*a[j] + b[i]*b[j] + c[i]*c[j] + sum[i]*sum[j])
+		}
+	}
+}
+
+func (mi *massIntegrals) properties() MassProperties {
+	p := MassProperties{Volume: mi.volume, Area: mi.area}
+	if mi.volume == 0 {
+		return p
+	}
+	var c [3]float64
+	for i := range c {
+		c[i] = mi.first[i] / mi.volume
+	}
+	p.Centroid = Point3D{float32(c[0]), float32(c[1]), float32(c[2])}
+	// Parallel axis theorem to move the second moments to the centroid.
+	var s [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			s[i][j] = mi.second[i][j] - mi.volume*c[i]*c[j]
+		}
+	}
+	trace := s[0][0] + s[1][1] + s[2][2]
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			p.Inertia[i][j] = -s[i][j]
+		}
+		p.Inertia[i][i] += trace
+	}
+	return p
+}
+
+func toFloat64(v Point3D) [3]float64 {
+	return [3]float64{float64(v[0]), float64(v[1]), float64(v[2])}
+}
+
+func sub64(a, b [3]float64) [3]float64 {
+	return [3]float64{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func dot64(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func cross64(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func norm64(a [3]float64) float64 {
+	return math.Sqrt(dot64(a, a))
+}
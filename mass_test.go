@@ -0,0 +1,123 @@
+package go3mf
+
+import (
+	"math"
+	"testing"
+)
+
+// newCube returns a closed mesh of a cube with the triangles oriented outwards.
+func newCube(origin Point3D, size float32) *Mesh {
+	m := new(Mesh)
+	for i := 0; i < 8; i++ {
+		m.Vertices = append(m.Vertices, Point3D{
+			origin[0] + float32(i&1)*size,
+			origin[1] + float32(i>>1&1)*size,
+			origin[2] + float32(i>>2&1)*size,
+		})
+	}
+	for _, t := range [][3]uint32{
+		{0, 2, 1}, {1, 2, 3}, {4, 5, 6}, {5, 7, 6}, {0, 1, 5}, {0, 5, 4},
+		{2, 6, 7}, {2, 7, 3}, {0, 4, 6}, {0, 6, 2}, {1, 3, 7}, {1, 7, 5},
+	} {
+		m.Triangles = append(m.Triangles, NewTriangle(t[0], t[1], t[2]))
+	}
+	return m
+}
+
+func massNear(got, want MassProperties) bool {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-5 }
+	if !near(got.Volume, want.Volume) || !near(got.Area, want.Area) {
+		return false
+	}
+	for i := 0; i < 3; i++ {
+		if !near(float64(got.Centroid[i]), float64(want.Centroid[i])) {
+			return false
+		}
+		for j := 0; j < 3; j++ {
+			if !near(got.Inertia[i][j], want.Inertia[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestMesh_MassProperties(t *testing.T) {
+	flipped := newCube(Point3D{}, 1)
+	for i, tr := range flipped.Triangles {
+		v1, v2, v3 := tr.Indices()
+		flipped.Triangles[i] = NewTriangle(v1, v3, v2)
+	}
+	tests := []struct {
+		name string
+		m    *Mesh
+		want MassProperties
+	}{
+		{"empty", new(Mesh), MassProperties{}},
+		{"cube", newCube(Point3D{}, 1), MassProperties{
+			Volume: 1, Area: 6, Centroid: Point3D{0.5, 0.5, 0.5},
+			Inertia: [3][3]float64{{1.0 / 6, 0, 0}, {0, 1.0 / 6, 0}, {0, 0, 1.0 / 6}},
+		}},
+		{"translated", newCube(Point3D{10, -5, 3}, 2), MassProperties{
+			Volume: 8, Area: 24, Centroid: Point3D{11, -4, 4},
+			Inertia: [3][3]float64{{8.0 * 8 / 12, 0, 0}, {0, 8.0 * 8 / 12, 0}, {0, 0, 8.0 * 8 / 12}},
+		}},
+		{"inwards", flipped, MassProperties{
+			Volume: -1, Area: 6, Centroid: Point3D{0.5, 0.5, 0.5},
+			Inertia: [3][3]float64{{-1.0 / 6, 0, 0}, {0, -1.0 / 6, 0}, {0, 0, -1.0 / 6}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.MassProperties(); !massNear(got, tt.want) {
+				t.Errorf("Mesh.MassProperties() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_MassProperties(t *testing.T) {
+	m := &Model{
+		Resources: Resources{Objects: []*Object{
+			{ID: 1, Mesh: newCube(Point3D{}, 1)},
+			{ID: 2, Components: []*Component{
+				{ObjectID: 1, Transform: Identity().Scale(-2, 2, 2)},
+			}},
+		}},
+		Build: Build{Items: []*Item{
+			{ObjectID: 1},
+			{ObjectID: 1, Transform: Identity().Translate(2, 0, 0)},
+		}},
+	}
+	got, err := m.ObjectMassProperties("", 2)
+	if err != nil {
+		t.Fatalf("Model.ObjectMassProperties() error = %v", err)
+	}
+	want := MassProperties{
+		Volume: 8, Area: 24, Centroid: Point3D{-1, 1, 1},
+		Inertia: [3][3]float64{{8.0 * 8 / 12, 0, 0}, {0, 8.0 * 8 / 12, 0}, {0, 0, 8.0 * 8 / 12}},
+	}
+	if !massNear(got, want) {
+		t.Errorf("Model.ObjectMassProperties() = %v, want %v", got, want)
+	}
+	if _, err := m.ObjectMassProperties("", 3); err == nil {
+		t.Error("Model.ObjectMassProperties() expected error")
+	}
+
+	got, err = m.BuildMassProperties()
+	if err != nil {
+		t.Fatalf("Model.BuildMassProperties() error = %v", err)
+	}
+	// Two unit cubes centered at x=0.5 and x=2.5.
+	want = MassProperties{
+		Volume: 2, Area: 12, Centroid: Point3D{1.5, 0.5, 0.5},
+		Inertia: [3][3]float64{{2.0 / 6, 0, 0}, {0, 2.0/6 + 2, 0}, {0, 0, 2.0/6 + 2}},
+	}
+	if !massNear(got, want) {
+		t.Errorf("Model.BuildMassProperties() = %v, want %v", got, want)
+	}
+	m.Build.Items = append(m.Build.Items, &Item{ObjectID: 5})
+	if _, err := m.BuildMassProperties(); err == nil {
+		t.Error("Model.BuildMassProperties() expected error")
+	}
+}
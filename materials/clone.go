@@ -0,0 +1,83 @@
+package materials
+
+import "image/color"
+
+// Clone returns a deep copy of r.
+func (r *Texture2D) Clone() interface{} {
+	c := *r
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *Texture2DGroup) Clone() interface{} {
+	c := *r
+	c.Coords = append([]TextureCoord(nil), r.Coords...)
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *ColorGroup) Clone() interface{} {
+	c := *r
+	c.Colors = append([]color.RGBA(nil), r.Colors...)
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *CompositeMaterials) Clone() interface{} {
+	c := *r
+	c.Indices = append([]uint32(nil), r.Indices...)
+	if r.Composites != nil {
+		c.Composites = make([]Composite, len(r.Composites))
+		for i, comp := range r.Composites {
+			c.Composites[i].Values = append([]float32(nil), comp.Values...)
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *MultiProperties) Clone() interface{} {
+	c := *r
+	c.PIDs = append([]uint32(nil), r.PIDs...)
+	c.BlendMethods = append([]BlendMethod(nil), r.BlendMethods...)
+	if r.Multis != nil {
+		c.Multis = make([]Multi, len(r.Multis))
+		for i, m := range r.Multis {
+			c.Multis[i].PIndices = append([]uint32(nil), m.PIndices...)
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *PBSpecularDisplayProperties) Clone() interface{} {
+	c := *r
+	c.Specular = append([]PBSpecular(nil), r.Specular...)
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *PBMetallicDisplayProperties) Clone() interface{} {
+	c := *r
+	c.Metallic = append([]PBMetallic(nil), r.Metallic...)
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *PBSpecularTextureDisplayProperties) Clone() interface{} {
+	c := *r
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *PBMetallicTextureDisplayProperties) Clone() interface{} {
+	c := *r
+	return &c
+}
+
+// Clone returns a deep copy of r.
+func (r *TranslucentDisplayProperties) Clone() interface{} {
+	c := *r
+	c.Translucent = append([]Translucent(nil), r.Translucent...)
+	return &c
+}
@@ -2,6 +2,7 @@ package materials
 
 import (
 	"encoding/xml"
+	"image/color"
 	"strconv"
 	"strings"
 
@@ -15,17 +16,31 @@ func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
 }
 
 func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	resources, ok := parent.(*go3mf.Resources)
+	if !ok {
+		return
+	}
 	switch name {
 	case attrColorGroup:
-		child = &colorGroupDecoder{resources: parent.(*go3mf.Resources)}
+		child = &colorGroupDecoder{resources: resources}
 	case attrTexture2DGroup:
-		child = &tex2DGroupDecoder{resources: parent.(*go3mf.Resources)}
+		child = &tex2DGroupDecoder{resources: resources}
 	case attrTexture2D:
-		child = &texture2DDecoder{resources: parent.(*go3mf.Resources)}
+		child = &texture2DDecoder{resources: resources}
 	case attrCompositematerials:
-		child = &compositeMaterialsDecoder{resources: parent.(*go3mf.Resources)}
+		child = &compositeMaterialsDecoder{resources: resources}
 	case attrMultiProps:
-		child = &multiPropertiesDecoder{resources: parent.(*go3mf.Resources)}
+		child = &multiPropertiesDecoder{resources: resources}
+	case attrPBSpecularDisplay:
+		child = &pbSpecularDisplayDecoder{resources: resources}
+	case attrPBMetallicDisplay:
+		child = &pbMetallicDisplayDecoder{resources: resources}
+	case attrPBSpecularTexture:
+		child = &pbSpecularTextureDecoder{resources: resources}
+	case attrPBMetallicTexture:
+		child = &pbMetallicTextureDecoder{resources: resources}
+	case attrTranslucentDisplay:
+		child = &translucentDisplayDecoder{resources: resources}
 	}
 	return
 }
@@ -55,13 +70,22 @@ func (d *colorGroupDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
 func (d *colorGroupDecoder) Start(attrs []spec.Attr) (errs error) {
 	d.colorDecoder.resource = &d.resource
 	for _, a := range attrs {
-		if a.Name.Space == "" && a.Name.Local == attrID {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrID:
 			id, err := strconv.ParseUint(string(a.Value), 10, 32)
 			if err != nil {
 				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
 			}
 			d.resource.ID = uint32(id)
-			break
+		case attrDisplayPropID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DisplayPropertiesID = uint32(val)
 		}
 	}
 	return
@@ -163,6 +187,12 @@ func (d *tex2DGroupDecoder) Start(attrs []spec.Attr) error {
 				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
 			}
 			d.resource.TextureID = uint32(val)
+		case attrDisplayPropID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DisplayPropertiesID = uint32(val)
 		}
 	}
 	if errs != nil {
@@ -347,6 +377,12 @@ func (d *multiPropertiesDecoder) Start(attrs []spec.Attr) error {
 				}
 				d.resource.PIDs = append(d.resource.PIDs, uint32(val))
 			}
+		case attrDisplayPropID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.DisplayPropertiesID = uint32(val)
 		}
 	}
 	if errs != nil {
@@ -383,6 +419,362 @@ func (d *multiDecoder) Start(attrs []spec.Attr) error {
 	return nil
 }
 
+type pbSpecularDisplayDecoder struct {
+	baseDecoder
+	resources       *go3mf.Resources
+	resource        PBSpecularDisplayProperties
+	specularDecoder pbSpecularDecoder
+}
+
+func (d *pbSpecularDisplayDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbSpecularDisplayDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *pbSpecularDisplayDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrPBSpecular {
+		child = &d.specularDecoder
+	}
+	return
+}
+
+func (d *pbSpecularDisplayDecoder) Start(attrs []spec.Attr) error {
+	d.specularDecoder.resource = &d.resource
+	id, err := parseID(attrs)
+	d.resource.ID = id
+	if err != nil {
+		return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type pbSpecularDecoder struct {
+	baseDecoder
+	resource *PBSpecularDisplayProperties
+}
+
+func (d *pbSpecularDecoder) Start(attrs []spec.Attr) error {
+	var (
+		errs     error
+		specular = PBSpecular{SpecularColor: color.RGBA{R: 0x38, G: 0x38, B: 0x38, A: 0xff}}
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			specular.Name = string(a.Value)
+		case attrSpecularColor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			specular.SpecularColor = c
+		case attrGlossiness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			specular.Glossiness = float32(val)
+		}
+	}
+	d.resource.Specular = append(d.resource.Specular, specular)
+	if errs != nil {
+		return specerr.WrapIndex(errs, specular, len(d.resource.Specular)-1)
+	}
+	return nil
+}
+
+type pbMetallicDisplayDecoder struct {
+	baseDecoder
+	resources       *go3mf.Resources
+	resource        PBMetallicDisplayProperties
+	metallicDecoder pbMetallicDecoder
+}
+
+func (d *pbMetallicDisplayDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbMetallicDisplayDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *pbMetallicDisplayDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrPBMetallic {
+		child = &d.metallicDecoder
+	}
+	return
+}
+
+func (d *pbMetallicDisplayDecoder) Start(attrs []spec.Attr) error {
+	d.metallicDecoder.resource = &d.resource
+	id, err := parseID(attrs)
+	d.resource.ID = id
+	if err != nil {
+		return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type pbMetallicDecoder struct {
+	baseDecoder
+	resource *PBMetallicDisplayProperties
+}
+
+func (d *pbMetallicDecoder) Start(attrs []spec.Attr) error {
+	var (
+		errs     error
+		metallic = PBMetallic{Roughness: 1}
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			metallic.Name = string(a.Value)
+		case attrMetallicness, attrRoughness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			if a.Name.Local == attrMetallicness {
+				metallic.Metallicness = float32(val)
+			} else {
+				metallic.Roughness = float32(val)
+			}
+		}
+	}
+	d.resource.Metallic = append(d.resource.Metallic, metallic)
+	if errs != nil {
+		return specerr.WrapIndex(errs, metallic, len(d.resource.Metallic)-1)
+	}
+	return nil
+}
+
+type pbSpecularTextureDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  PBSpecularTextureDisplayProperties
+}
+
+func (d *pbSpecularTextureDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbSpecularTextureDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	d.resource.DiffuseFactor, d.resource.SpecularFactor, d.resource.GlossinessFactor = white, white, 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			d.resource.Name = string(a.Value)
+		case attrID, attrSpecularTextureID, attrGlossinessTexID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			switch a.Name.Local {
+			case attrID:
+				d.resource.ID = uint32(val)
+			case attrSpecularTextureID:
+				d.resource.SpecularTextureID = uint32(val)
+			default:
+				d.resource.GlossinessTextureID = uint32(val)
+			}
+		case attrDiffuseFactor, attrSpecularFactor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			if a.Name.Local == attrDiffuseFactor {
+				d.resource.DiffuseFactor = c
+			} else {
+				d.resource.SpecularFactor = c
+			}
+		case attrGlossinessFactor:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.GlossinessFactor = float32(val)
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type pbMetallicTextureDecoder struct {
+	baseDecoder
+	resources *go3mf.Resources
+	resource  PBMetallicTextureDisplayProperties
+}
+
+func (d *pbMetallicTextureDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *pbMetallicTextureDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.resource.BaseColorFactor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	d.resource.MetallicFactor, d.resource.RoughnessFactor = 1, 1
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			d.resource.Name = string(a.Value)
+		case attrID, attrMetallicTextureID, attrRoughnessTextureID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			switch a.Name.Local {
+			case attrID:
+				d.resource.ID = uint32(val)
+			case attrMetallicTextureID:
+				d.resource.MetallicTextureID = uint32(val)
+			default:
+				d.resource.RoughnessTextureID = uint32(val)
+			}
+		case attrBaseColorFactor:
+			c, err := spec.ParseRGBA(string(a.Value))
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			d.resource.BaseColorFactor = c
+		case attrMetallicFactor, attrRoughnessFactor:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			if a.Name.Local == attrMetallicFactor {
+				d.resource.MetallicFactor = float32(val)
+			} else {
+				d.resource.RoughnessFactor = float32(val)
+			}
+		}
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type translucentDisplayDecoder struct {
+	baseDecoder
+	resources          *go3mf.Resources
+	resource           TranslucentDisplayProperties
+	translucentDecoder translucentDecoder
+}
+
+func (d *translucentDisplayDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *translucentDisplayDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *translucentDisplayDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrTranslucent {
+		child = &d.translucentDecoder
+	}
+	return
+}
+
+func (d *translucentDisplayDecoder) Start(attrs []spec.Attr) error {
+	d.translucentDecoder.resource = &d.resource
+	id, err := parseID(attrs)
+	d.resource.ID = id
+	if err != nil {
+		return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type translucentDecoder struct {
+	baseDecoder
+	resource *TranslucentDisplayProperties
+}
+
+func (d *translucentDecoder) Start(attrs []spec.Attr) error {
+	var (
+		errs        error
+		translucent = Translucent{RefractiveIndex: [3]float32{1, 1, 1}}
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			translucent.Name = string(a.Value)
+		case attrAttenuation:
+			var ok bool
+			if translucent.Attenuation, ok = parseRGBFloats(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+		case attrRefractiveIndex:
+			var ok bool
+			if translucent.RefractiveIndex, ok = parseRGBFloats(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrRoughness:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+			translucent.Roughness = float32(val)
+		}
+	}
+	d.resource.Translucent = append(d.resource.Translucent, translucent)
+	if errs != nil {
+		return specerr.WrapIndex(errs, translucent, len(d.resource.Translucent)-1)
+	}
+	return nil
+}
+
+func parseID(attrs []spec.Attr) (uint32, error) {
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrID {
+			id, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				return 0, specerr.NewParseAttrError(a.Name.Local, true)
+			}
+			return uint32(id), nil
+		}
+	}
+	return 0, nil
+}
+
+func parseRGBFloats(s string) (v [3]float32, ok bool) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return v, false
+	}
+	for i, f := range fields {
+		val, err := strconv.ParseFloat(f, 32)
+		if err != nil {
+			return v, false
+		}
+		v[i] = float32(val)
+	}
+	return v, true
+}
+
 type baseDecoder struct {
 }
 
@@ -12,36 +12,71 @@ import (
 
 func TestDecode(t *testing.T) {
 	baseTexture := &Texture2D{ID: 6, Path: "/3D/Texture/msLogo.png", ContentType: TextureTypePNG, TileStyleU: TileWrap, TileStyleV: TileMirror, Filter: TextureFilterAuto}
-	colorGroup := &ColorGroup{ID: 1, Colors: []color.RGBA{{R: 255, G: 255, B: 255, A: 255}, {R: 0, G: 0, B: 0, A: 255}, {R: 26, G: 181, B: 103, A: 255}, {R: 223, G: 4, B: 90, A: 255}}}
-	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
+	colorGroup := &ColorGroup{ID: 1, DisplayPropertiesID: 11, Colors: []color.RGBA{{R: 255, G: 255, B: 255, A: 255}, {R: 0, G: 0, B: 0, A: 255}, {R: 26, G: 181, B: 103, A: 255}, {R: 223, G: 4, B: 90, A: 255}}}
+	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, DisplayPropertiesID: 12, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
 	compositeGroup := &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}, {Values: []float32{0.2, 0.8}}}}
-	multiGroup := &MultiProperties{ID: 9, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	multiGroup := &MultiProperties{ID: 9, DisplayPropertiesID: 10, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	specularDisplay := &PBSpecularDisplayProperties{ID: 10, Specular: []PBSpecular{
+		{Name: "Red", SpecularColor: color.RGBA{R: 56, G: 56, B: 56, A: 255}, Glossiness: 0.5},
+		{Name: "Blue", SpecularColor: color.RGBA{R: 255, G: 0, B: 0, A: 255}},
+	}}
+	metallicDisplay := &PBMetallicDisplayProperties{ID: 11, Metallic: []PBMetallic{
+		{Name: "Gold", Metallicness: 1, Roughness: 0.2},
+		{Name: "Plastic", Roughness: 1},
+	}}
+	specularTexture := &PBSpecularTextureDisplayProperties{
+		ID: 12, Name: "Texture", SpecularTextureID: 6, GlossinessTextureID: 6,
+		DiffuseFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, SpecularFactor: color.RGBA{R: 255, G: 0, B: 0, A: 255}, GlossinessFactor: 1,
+	}
+	metallicTexture := &PBMetallicTextureDisplayProperties{
+		ID: 13, Name: "Texture", MetallicTextureID: 6, RoughnessTextureID: 6,
+		BaseColorFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, MetallicFactor: 0.5, RoughnessFactor: 1,
+	}
+	translucentDisplay := &TranslucentDisplayProperties{ID: 14, Translucent: []Translucent{
+		{Name: "Glass", Attenuation: [3]float32{0.1, 0.2, 0.3}, RefractiveIndex: [3]float32{1, 1, 1}, Roughness: 0.1},
+		{Name: "Water", Attenuation: [3]float32{1, 1, 1}, RefractiveIndex: [3]float32{1.3, 1.3, 1.3}},
+	}}
 	want := &go3mf.Model{
 		Path:       "/3D/3dmodel.model",
 		Extensions: []go3mf.Extension{DefaultExtension},
 	}
-	want.Resources.Assets = append(want.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup)
+	want.Resources.Assets = append(want.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup,
+		specularDisplay, metallicDisplay, specularTexture, metallicTexture, translucentDisplay)
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
 	rootFile := `
 	<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:m="http://schemas.microsoft.com/3dmanufacturing/material/2015/02">
 		<resources>
 			<m:texture2d id="6" path="/3D/Texture/msLogo.png" contenttype="image/png" tilestyleu="wrap" tilestylev="mirror" filter="auto" />
-			<m:colorgroup id="1">
+			<m:colorgroup id="1" displaypropertiesid="11">
 				<m:color color="#FFFFFF" /> <m:color color="#000000" /> <m:color color="#1AB567" /> <m:color color="#DF045A" />
 			</m:colorgroup>
-			<m:texture2dgroup id="2" texid="6">
+			<m:texture2dgroup id="2" texid="6" displaypropertiesid="12">
 				<m:tex2coord u="0.3" v="0.5" /> <m:tex2coord u="0.3" v="0.8" />	<m:tex2coord u="0.5" v="0.8" />	<m:tex2coord u="0.5" v="0.5" />
 			</m:texture2dgroup>
 			<m:compositematerials id="4" matid="5" matindices="1 2">
 				<m:composite values="0.5 0.5"/>
 				<m:composite values="0.2 0.8"/>
 			</m:compositematerials>
-			<m:multiproperties id="9" pids="5 2" blendmethods="multiply">
+			<m:multiproperties id="9" pids="5 2" blendmethods="multiply" displaypropertiesid="10">
 				<m:multi pindices="0 0" />
 				<m:multi pindices="1 0" />
 				<m:multi pindices="2 3" />
 			</m:multiproperties>
+			<m:pbspeculardisplayproperties id="10">
+				<m:pbspecular name="Red" glossiness="0.5" />
+				<m:pbspecular name="Blue" specularcolor="#FF0000" />
+			</m:pbspeculardisplayproperties>
+			<m:pbmetallicdisplayproperties id="11">
+				<m:pbmetallic name="Gold" metallicness="1" roughness="0.2" />
+				<m:pbmetallic name="Plastic" />
+			</m:pbmetallicdisplayproperties>
+			<m:pbspeculartexturedisplayproperties id="12" name="Texture" speculartextureid="6" glossinesstextureid="6" specularfactor="#FF0000" />
+			<m:pbmetallictexturedisplayproperties id="13" name="Texture" metallictextureid="6" roughnesstextureid="6" metallicfactor="0.5" />
+			<m:translucentdisplayproperties id="14">
+				<m:translucent name="Glass" attenuation="0.1 0.2 0.3" roughness="0.1" />
+				<m:translucent name="Water" attenuation="1 1 1" refractiveindex="1.3 1.3 1.3" />
+			</m:translucentdisplayproperties>
 		</resources>
 		<build>
 		</build>
@@ -68,6 +103,10 @@ func TestDecode_warns(t *testing.T) {
 		fmt.Sprintf("Resources@CompositeMaterials#4: %v", errors.NewParseAttrError("matid", true)),
 		fmt.Sprintf("Resources@CompositeMaterials#4@Composite#1: %v", errors.NewParseAttrError("values", true)),
 		fmt.Sprintf("Resources@MultiProperties#5: %v", errors.NewParseAttrError("pids", true)),
+		fmt.Sprintf("Resources@PBSpecularDisplayProperties#7: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@PBSpecularDisplayProperties#7@PBSpecular#0: %v", errors.NewParseAttrError("glossiness", false)),
+		fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#8: %v", errors.NewParseAttrError("metallictextureid", true)),
+		fmt.Sprintf("Resources@TranslucentDisplayProperties#9@Translucent#0: %v", errors.NewParseAttrError("attenuation", true)),
 	}
 	got := new(go3mf.Model)
 	got.Path = "/3D/3dmodel.model"
@@ -90,6 +129,13 @@ func TestDecode_warns(t *testing.T) {
 				<m:multi />
 			</m:multiproperties>
 			<m:multiproperties id="19" />
+			<m:pbspeculardisplayproperties id="a">
+				<m:pbspecular name="Red" glossiness="b" />
+			</m:pbspeculardisplayproperties>
+			<m:pbmetallictexturedisplayproperties id="13" name="Texture" metallictextureid="c" roughnesstextureid="6" />
+			<m:translucentdisplayproperties id="14">
+				<m:translucent name="Glass" attenuation="0.1 0.2" />
+			</m:translucentdisplayproperties>
 			<object id="8" name="Box 1" pid="5" pindex="0" type="model">
 				<mesh>
 					<vertices>
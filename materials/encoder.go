@@ -13,6 +13,9 @@ func (r *ColorGroup) Marshal3MF(x spec.Encoder) error {
 	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrColorGroup}, Attr: []xml.Attr{
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 	}}
+	if r.DisplayPropertiesID != 0 {
+		xs.Attr = append(xs.Attr, displayPropsAttr(r.DisplayPropertiesID))
+	}
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	x.SetSkipAttrEscape(true)
@@ -35,6 +38,9 @@ func (r *Texture2DGroup) Marshal3MF(x spec.Encoder) error {
 		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
 		{Name: xml.Name{Local: attrTexID}, Value: strconv.FormatUint(uint64(r.TextureID), 10)},
 	}}
+	if r.DisplayPropertiesID != 0 {
+		xs.Attr = append(xs.Attr, displayPropsAttr(r.DisplayPropertiesID))
+	}
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	x.SetSkipAttrEscape(true)
@@ -98,6 +104,9 @@ func (r *MultiProperties) Marshal3MF(x spec.Encoder) error {
 		{Name: xml.Name{Local: attrPIDs}, Value: strings.Join(pids, " ")},
 		{Name: xml.Name{Local: attrBlendMethods}, Value: strings.Join(methods, " ")},
 	}}
+	if r.DisplayPropertiesID != 0 {
+		xs.Attr = append(xs.Attr, displayPropsAttr(r.DisplayPropertiesID))
+	}
 	x.EncodeToken(xs)
 	x.SetAutoClose(true)
 	x.SetSkipAttrEscape(true)
@@ -138,3 +147,109 @@ func (r *Texture2D) Marshal3MF(x spec.Encoder) error {
 	x.SetAutoClose(false)
 	return nil
 }
+
+// Marshal3MF encodes the resource.
+func (r *PBSpecularDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecularDisplay}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	prec := x.FloatPresicion()
+	for _, s := range r.Specular {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecular}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: s.Name},
+			{Name: xml.Name{Local: attrSpecularColor}, Value: spec.FormatRGBA(s.SpecularColor)},
+			{Name: xml.Name{Local: attrGlossiness}, Value: strconv.FormatFloat(float64(s.Glossiness), 'f', prec, 32)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBMetallicDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallicDisplay}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	prec := x.FloatPresicion()
+	for _, m := range r.Metallic {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallic}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: m.Name},
+			{Name: xml.Name{Local: attrMetallicness}, Value: strconv.FormatFloat(float64(m.Metallicness), 'f', prec, 32)},
+			{Name: xml.Name{Local: attrRoughness}, Value: strconv.FormatFloat(float64(m.Roughness), 'f', prec, 32)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBSpecularTextureDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBSpecularTexture}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrName}, Value: r.Name},
+		{Name: xml.Name{Local: attrSpecularTextureID}, Value: strconv.FormatUint(uint64(r.SpecularTextureID), 10)},
+		{Name: xml.Name{Local: attrGlossinessTexID}, Value: strconv.FormatUint(uint64(r.GlossinessTextureID), 10)},
+		{Name: xml.Name{Local: attrDiffuseFactor}, Value: spec.FormatRGBA(r.DiffuseFactor)},
+		{Name: xml.Name{Local: attrSpecularFactor}, Value: spec.FormatRGBA(r.SpecularFactor)},
+		{Name: xml.Name{Local: attrGlossinessFactor}, Value: strconv.FormatFloat(float64(r.GlossinessFactor), 'f', x.FloatPresicion(), 32)},
+	}}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *PBMetallicTextureDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	prec := x.FloatPresicion()
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrPBMetallicTexture}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrName}, Value: r.Name},
+		{Name: xml.Name{Local: attrMetallicTextureID}, Value: strconv.FormatUint(uint64(r.MetallicTextureID), 10)},
+		{Name: xml.Name{Local: attrRoughnessTextureID}, Value: strconv.FormatUint(uint64(r.RoughnessTextureID), 10)},
+		{Name: xml.Name{Local: attrBaseColorFactor}, Value: spec.FormatRGBA(r.BaseColorFactor)},
+		{Name: xml.Name{Local: attrMetallicFactor}, Value: strconv.FormatFloat(float64(r.MetallicFactor), 'f', prec, 32)},
+		{Name: xml.Name{Local: attrRoughnessFactor}, Value: strconv.FormatFloat(float64(r.RoughnessFactor), 'f', prec, 32)},
+	}}
+	x.SetAutoClose(true)
+	x.EncodeToken(xs)
+	x.SetAutoClose(false)
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (r *TranslucentDisplayProperties) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTranslucentDisplay}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	prec := x.FloatPresicion()
+	for _, t := range r.Translucent {
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrTranslucent}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: t.Name},
+			{Name: xml.Name{Local: attrAttenuation}, Value: formatRGBFloats(t.Attenuation, prec)},
+			{Name: xml.Name{Local: attrRefractiveIndex}, Value: formatRGBFloats(t.RefractiveIndex, prec)},
+			{Name: xml.Name{Local: attrRoughness}, Value: strconv.FormatFloat(float64(t.Roughness), 'f', prec, 32)},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+func displayPropsAttr(id uint32) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: attrDisplayPropID}, Value: strconv.FormatUint(uint64(id), 10)}
+}
+
+func formatRGBFloats(v [3]float32, prec int) string {
+	return strconv.FormatFloat(float64(v[0]), 'f', prec, 32) + " " +
+		strconv.FormatFloat(float64(v[1]), 'f', prec, 32) + " " +
+		strconv.FormatFloat(float64(v[2]), 'f', prec, 32)
+}
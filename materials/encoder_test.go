@@ -13,9 +13,29 @@ func TestMarshalModel(t *testing.T) {
 	colorGroup := &ColorGroup{ID: 1, Colors: []color.RGBA{{R: 255, G: 255, B: 255, A: 255}, {R: 0, G: 0, B: 0, A: 255}, {R: 26, G: 181, B: 103, A: 255}, {R: 223, G: 4, B: 90, A: 255}}}
 	texGroup := &Texture2DGroup{ID: 2, TextureID: 6, Coords: []TextureCoord{{0.3, 0.5}, {0.3, 0.8}, {0.5, 0.8}, {0.5, 0.5}}}
 	compositeGroup := &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}, {Values: []float32{0.2, 0.8}}}}
-	multiGroup := &MultiProperties{ID: 9, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	multiGroup := &MultiProperties{ID: 9, DisplayPropertiesID: 10, BlendMethods: []BlendMethod{BlendMultiply}, PIDs: []uint32{5, 2}, Multis: []Multi{{PIndices: []uint32{0, 0}}, {PIndices: []uint32{1, 0}}, {PIndices: []uint32{2, 3}}}}
+	specularDisplay := &PBSpecularDisplayProperties{ID: 10, Specular: []PBSpecular{
+		{Name: "Red", SpecularColor: color.RGBA{R: 56, G: 56, B: 56, A: 255}, Glossiness: 0.5},
+	}}
+	metallicDisplay := &PBMetallicDisplayProperties{ID: 11, Metallic: []PBMetallic{
+		{Name: "Gold", Metallicness: 1, Roughness: 0.2},
+	}}
+	specularTexture := &PBSpecularTextureDisplayProperties{
+		ID: 12, Name: "Texture", SpecularTextureID: 6, GlossinessTextureID: 6,
+		DiffuseFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, SpecularFactor: color.RGBA{R: 255, G: 0, B: 0, A: 255}, GlossinessFactor: 1,
+	}
+	metallicTexture := &PBMetallicTextureDisplayProperties{
+		ID: 13, Name: "Texture", MetallicTextureID: 6, RoughnessTextureID: 6,
+		BaseColorFactor: color.RGBA{R: 255, G: 255, B: 255, A: 255}, MetallicFactor: 0.5, RoughnessFactor: 1,
+	}
+	translucentDisplay := &TranslucentDisplayProperties{ID: 14, Translucent: []Translucent{
+		{Name: "Glass", Attenuation: [3]float32{0.1, 0.2, 0.3}, RefractiveIndex: [3]float32{1, 1, 1}, Roughness: 0.1},
+	}}
+	colorGroup.DisplayPropertiesID = 11
+	texGroup.DisplayPropertiesID = 12
 	m := &go3mf.Model{Path: "/3D/3dmodel.model"}
-	m.Resources.Assets = append(m.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup)
+	m.Resources.Assets = append(m.Resources.Assets, baseTexture, colorGroup, texGroup, compositeGroup, multiGroup,
+		specularDisplay, metallicDisplay, specularTexture, metallicTexture, translucentDisplay)
 	m.Extensions = []go3mf.Extension{DefaultExtension}
 	t.Run("base", func(t *testing.T) {
 		b, err := go3mf.MarshalModel(m)
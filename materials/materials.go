@@ -34,6 +34,7 @@ var (
 	ErrTextureReference   = errors.New("MUST reference to a texture resource")
 	ErrCompositeBase      = errors.New("MUST reference to a basematerials group")
 	ErrMissingTexturePart = errors.New("texture part MUST be added as an attachment")
+	ErrDisplayPropsRef    = errors.New("MUST reference to a compatible display properties resource")
 )
 
 // Texture2DType defines the allowed texture 2D types.
@@ -136,9 +137,10 @@ func (t TextureCoord) V() float32 {
 
 // Texture2DGroup acts as a container for texture coordinate properties.
 type Texture2DGroup struct {
-	ID        uint32
-	TextureID uint32
-	Coords    []TextureCoord
+	ID                  uint32
+	TextureID           uint32
+	DisplayPropertiesID uint32
+	Coords              []TextureCoord
 }
 
 // Len returns the materials count.
@@ -153,8 +155,9 @@ func (r *Texture2DGroup) Identify() uint32 {
 
 // ColorGroup acts as a container for color properties.
 type ColorGroup struct {
-	ID     uint32
-	Colors []color.RGBA
+	ID                  uint32
+	DisplayPropertiesID uint32
+	Colors              []color.RGBA
 }
 
 // Len returns the materials count.
@@ -198,10 +201,11 @@ type Multi struct {
 // A MultiProperties element acts as a container for Multi
 // elements which are indexable groups of property indices.
 type MultiProperties struct {
-	ID           uint32
-	PIDs         []uint32
-	BlendMethods []BlendMethod
-	Multis       []Multi
+	ID                  uint32
+	DisplayPropertiesID uint32
+	PIDs                []uint32
+	BlendMethods        []BlendMethod
+	Multis              []Multi
 }
 
 // Len returns the materials count.
@@ -214,6 +218,98 @@ func (c *MultiProperties) Identify() uint32 {
 	return c.ID
 }
 
+// PBSpecular defines the properties of a material
+// following the specular-glossiness workflow.
+type PBSpecular struct {
+	Name          string
+	SpecularColor color.RGBA
+	Glossiness    float32
+}
+
+// PBSpecularDisplayProperties acts as a container for PBSpecular properties.
+type PBSpecularDisplayProperties struct {
+	ID       uint32
+	Specular []PBSpecular
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBSpecularDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// PBMetallic defines the properties of a material
+// following the metallic-roughness workflow.
+type PBMetallic struct {
+	Name         string
+	Metallicness float32
+	Roughness    float32
+}
+
+// PBMetallicDisplayProperties acts as a container for PBMetallic properties.
+type PBMetallicDisplayProperties struct {
+	ID       uint32
+	Metallic []PBMetallic
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBMetallicDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// PBSpecularTextureDisplayProperties defines textured properties
+// following the specular-glossiness workflow.
+type PBSpecularTextureDisplayProperties struct {
+	ID                  uint32
+	Name                string
+	SpecularTextureID   uint32
+	GlossinessTextureID uint32
+	DiffuseFactor       color.RGBA
+	SpecularFactor      color.RGBA
+	GlossinessFactor    float32
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBSpecularTextureDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// PBMetallicTextureDisplayProperties defines textured properties
+// following the metallic-roughness workflow.
+type PBMetallicTextureDisplayProperties struct {
+	ID                 uint32
+	Name               string
+	MetallicTextureID  uint32
+	RoughnessTextureID uint32
+	BaseColorFactor    color.RGBA
+	MetallicFactor     float32
+	RoughnessFactor    float32
+}
+
+// Identify returns the unique ID of the resource.
+func (r *PBMetallicTextureDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
+// Translucent defines the properties of a translucent material.
+// Attenuation and RefractiveIndex are defined for the red, green and blue channels.
+type Translucent struct {
+	Name            string
+	Attenuation     [3]float32
+	RefractiveIndex [3]float32
+	Roughness       float32
+}
+
+// TranslucentDisplayProperties acts as a container for Translucent properties.
+type TranslucentDisplayProperties struct {
+	ID          uint32
+	Translucent []Translucent
+}
+
+// Identify returns the unique ID of the resource.
+func (r *TranslucentDisplayProperties) Identify() uint32 {
+	return r.ID
+}
+
 func newTexture2DType(s string) (t Texture2DType, ok bool) {
 	t, ok = map[string]Texture2DType{
 		"image/png":  TextureTypePNG,
@@ -274,4 +370,30 @@ const (
 	attrPIndices           = "pindices"
 	attrPIDs               = "pids"
 	attrBlendMethods       = "blendmethods"
+	attrDisplayPropID      = "displaypropertiesid"
+	attrName               = "name"
+	attrPBSpecularDisplay  = "pbspeculardisplayproperties"
+	attrPBSpecular         = "pbspecular"
+	attrSpecularColor      = "specularcolor"
+	attrGlossiness         = "glossiness"
+	attrPBMetallicDisplay  = "pbmetallicdisplayproperties"
+	attrPBMetallic         = "pbmetallic"
+	attrMetallicness       = "metallicness"
+	attrRoughness          = "roughness"
+	attrPBSpecularTexture  = "pbspeculartexturedisplayproperties"
+	attrSpecularTextureID  = "speculartextureid"
+	attrGlossinessTexID    = "glossinesstextureid"
+	attrDiffuseFactor      = "diffusefactor"
+	attrSpecularFactor     = "specularfactor"
+	attrGlossinessFactor   = "glossinessfactor"
+	attrPBMetallicTexture  = "pbmetallictexturedisplayproperties"
+	attrMetallicTextureID  = "metallictextureid"
+	attrRoughnessTextureID = "roughnesstextureid"
+	attrBaseColorFactor    = "basecolorfactor"
+	attrMetallicFactor     = "metallicfactor"
+	attrRoughnessFactor    = "roughnessfactor"
+	attrTranslucentDisplay = "translucentdisplayproperties"
+	attrTranslucent        = "translucent"
+	attrAttenuation        = "attenuation"
+	attrRefractiveIndex    = "refractiveindex"
 )
@@ -19,6 +19,16 @@ var _ spec.Marshaler = new(Texture2DGroup)
 var _ spec.Marshaler = new(CompositeMaterials)
 var _ spec.Marshaler = new(ColorGroup)
 var _ spec.Marshaler = new(MultiProperties)
+var _ go3mf.Asset = new(PBSpecularDisplayProperties)
+var _ go3mf.Asset = new(PBMetallicDisplayProperties)
+var _ go3mf.Asset = new(PBSpecularTextureDisplayProperties)
+var _ go3mf.Asset = new(PBMetallicTextureDisplayProperties)
+var _ go3mf.Asset = new(TranslucentDisplayProperties)
+var _ spec.Marshaler = new(PBSpecularDisplayProperties)
+var _ spec.Marshaler = new(PBMetallicDisplayProperties)
+var _ spec.Marshaler = new(PBSpecularTextureDisplayProperties)
+var _ spec.Marshaler = new(PBMetallicTextureDisplayProperties)
+var _ spec.Marshaler = new(TranslucentDisplayProperties)
 var _ spec.PropertyGroup = new(ColorGroup)
 var _ spec.PropertyGroup = new(Texture2DGroup)
 var _ spec.PropertyGroup = new(CompositeMaterials)
@@ -391,3 +401,108 @@ func TestTexture2DGroup_Len(t *testing.T) {
 		})
 	}
 }
+
+func TestDisplayProperties_Identify(t *testing.T) {
+	tests := []struct {
+		name string
+		r    go3mf.Asset
+		want uint32
+	}{
+		{"specular", &PBSpecularDisplayProperties{ID: 1}, 1},
+		{"metallic", &PBMetallicDisplayProperties{ID: 2}, 2},
+		{"specularTexture", &PBSpecularTextureDisplayProperties{ID: 3}, 3},
+		{"metallicTexture", &PBMetallicTextureDisplayProperties{ID: 4}, 4},
+		{"translucent", &TranslucentDisplayProperties{ID: 5}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Identify(); got != tt.want {
+				t.Errorf("%T.Identify() got = %v, want %v", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"texturegroup", func() spec.Cloner {
+			return &Texture2DGroup{ID: 2, TextureID: 1, Coords: []TextureCoord{{0.1, 0.2}}}
+		}, func(c interface{}) { c.(*Texture2DGroup).Coords[0] = TextureCoord{0.3, 0.4} }},
+		{"colorgroup", func() spec.Cloner {
+			return &ColorGroup{ID: 3, Colors: []color.RGBA{{R: 255, A: 255}}}
+		}, func(c interface{}) { c.(*ColorGroup).Colors[0].R = 0 }},
+		{"composite", func() spec.Cloner {
+			return &CompositeMaterials{ID: 4, MaterialID: 5, Indices: []uint32{1, 2}, Composites: []Composite{{Values: []float32{0.5, 0.5}}}}
+		}, func(c interface{}) {
+			c.(*CompositeMaterials).Indices[0] = 0
+			c.(*CompositeMaterials).Composites[0].Values[0] = 1
+		}},
+		{"multi", func() spec.Cloner {
+			return &MultiProperties{ID: 6, PIDs: []uint32{3, 4}, BlendMethods: []BlendMethod{BlendMultiply}, Multis: []Multi{{PIndices: []uint32{0, 1}}}}
+		}, func(c interface{}) {
+			c.(*MultiProperties).PIDs[0] = 0
+			c.(*MultiProperties).BlendMethods[0] = BlendMix
+			c.(*MultiProperties).Multis[0].PIndices[0] = 2
+		}},
+		{"pbspecular", func() spec.Cloner {
+			return &PBSpecularDisplayProperties{ID: 7, Specular: []PBSpecular{{Name: "a", Glossiness: 0.5}}}
+		}, func(c interface{}) { c.(*PBSpecularDisplayProperties).Specular[0].Name = "b" }},
+		{"pbmetallic", func() spec.Cloner {
+			return &PBMetallicDisplayProperties{ID: 8, Metallic: []PBMetallic{{Name: "a", Metallicness: 0.5}}}
+		}, func(c interface{}) { c.(*PBMetallicDisplayProperties).Metallic[0].Name = "b" }},
+		{"translucent", func() spec.Cloner {
+			return &TranslucentDisplayProperties{ID: 11, Translucent: []Translucent{{Name: "a", Roughness: 0.1}}}
+		}, func(c interface{}) { c.(*TranslucentDisplayProperties).Translucent[0].Name = "b" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"texture", &Texture2D{ID: 1, Path: "/a.png"}, &Texture2D{ID: 11, Path: "/a.png_1"}},
+		{"texturegroup", &Texture2DGroup{ID: 2, TextureID: 1, DisplayPropertiesID: 3}, &Texture2DGroup{ID: 12, TextureID: 11, DisplayPropertiesID: 13}},
+		{"colorgroup", &ColorGroup{ID: 3, DisplayPropertiesID: 1}, &ColorGroup{ID: 13, DisplayPropertiesID: 11}},
+		{"composite", &CompositeMaterials{ID: 4, MaterialID: 5}, &CompositeMaterials{ID: 14, MaterialID: 15}},
+		{"multi", &MultiProperties{ID: 6, DisplayPropertiesID: 1, PIDs: []uint32{3, 4}}, &MultiProperties{ID: 16, DisplayPropertiesID: 11, PIDs: []uint32{13, 14}}},
+		{"pbspecular", &PBSpecularDisplayProperties{ID: 7}, &PBSpecularDisplayProperties{ID: 17}},
+		{"pbmetallic", &PBMetallicDisplayProperties{ID: 8}, &PBMetallicDisplayProperties{ID: 18}},
+		{"pbspeculartexture", &PBSpecularTextureDisplayProperties{ID: 9, SpecularTextureID: 1, GlossinessTextureID: 2}, &PBSpecularTextureDisplayProperties{ID: 19, SpecularTextureID: 11, GlossinessTextureID: 12}},
+		{"pbmetallictexture", &PBMetallicTextureDisplayProperties{ID: 10, MetallicTextureID: 1, RoughnessTextureID: 2}, &PBMetallicTextureDisplayProperties{ID: 20, MetallicTextureID: 11, RoughnessTextureID: 12}},
+		{"translucent", &TranslucentDisplayProperties{ID: 11}, &TranslucentDisplayProperties{ID: 21}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
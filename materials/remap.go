@@ -0,0 +1,68 @@
+package materials
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *Texture2D) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
+
+// RemapPaths replaces the texture path with the one returned by fn.
+func (r *Texture2D) RemapPaths(fn func(string) string) {
+	r.Path = fn(r.Path)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *Texture2DGroup) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.TextureID = fn(r.TextureID)
+	r.DisplayPropertiesID = fn(r.DisplayPropertiesID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *ColorGroup) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.DisplayPropertiesID = fn(r.DisplayPropertiesID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *CompositeMaterials) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.MaterialID = fn(r.MaterialID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *MultiProperties) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.DisplayPropertiesID = fn(r.DisplayPropertiesID)
+	for i, pid := range r.PIDs {
+		r.PIDs[i] = fn(pid)
+	}
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *PBSpecularDisplayProperties) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *PBMetallicDisplayProperties) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *PBSpecularTextureDisplayProperties) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.SpecularTextureID = fn(r.SpecularTextureID)
+	r.GlossinessTextureID = fn(r.GlossinessTextureID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *PBMetallicTextureDisplayProperties) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.MetallicTextureID = fn(r.MetallicTextureID)
+	r.RoughnessTextureID = fn(r.RoughnessTextureID)
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *TranslucentDisplayProperties) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
@@ -18,7 +18,7 @@ func (Spec) Validate(model interface{}, path string, asset interface{}) error {
 func validateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
 	switch r := r.(type) {
 	case *ColorGroup:
-		errs = validateColorGroup(path, r)
+		errs = validateColorGroup(m, path, r)
 	case *Texture2DGroup:
 		errs = validateTexture2DGroup(m, path, r)
 	case *Texture2D:
@@ -27,11 +27,23 @@ func validateAsset(m *go3mf.Model, path string, r go3mf.Asset) (errs error) {
 		errs = validateMultiProps(m, path, r)
 	case *CompositeMaterials:
 		errs = validateCompositeMat(m, path, r)
+	case *PBSpecularDisplayProperties:
+		errs = validatePBSpecularDisplay(r)
+	case *PBMetallicDisplayProperties:
+		errs = validatePBMetallicDisplay(r)
+	case *PBSpecularTextureDisplayProperties:
+		errs = validatePBSpecularTexture(m, path, r)
+	case *PBMetallicTextureDisplayProperties:
+		errs = validatePBMetallicTexture(m, path, r)
+	case *TranslucentDisplayProperties:
+		errs = validateTranslucentDisplay(r)
+	case *go3mf.BaseMaterials:
+		errs = validateDisplayPropsRef(m, path, r.DisplayPropertiesID, false)
 	}
 	return
 }
 
-func validateColorGroup(path string, r *ColorGroup) (errs error) {
+func validateColorGroup(m *go3mf.Model, path string, r *ColorGroup) (errs error) {
 	if r.ID == 0 {
 		errs = errors.Append(errs, errors.ErrMissingID)
 	}
@@ -43,6 +55,7 @@ func validateColorGroup(path string, r *ColorGroup) (errs error) {
 			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrColor), c, j))
 		}
 	}
+	errs = errors.Append(errs, validateDisplayPropsRef(m, path, r.DisplayPropertiesID, false))
 	return
 }
 
@@ -62,6 +75,7 @@ func validateTexture2DGroup(m *go3mf.Model, path string, r *Texture2DGroup) (err
 	if len(r.Coords) == 0 {
 		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
 	}
+	errs = errors.Append(errs, validateDisplayPropsRef(m, path, r.DisplayPropertiesID, true))
 	return
 }
 
@@ -142,6 +156,7 @@ func validateMultiProps(m *go3mf.Model, path string, r *MultiProperties) (errs e
 			}
 		}
 	}
+	errs = errors.Append(errs, validateDisplayPropsRef(m, path, r.DisplayPropertiesID, false))
 	return
 }
 
@@ -173,3 +188,106 @@ func validateCompositeMat(m *go3mf.Model, path string, r *CompositeMaterials) (e
 	}
 	return
 }
+
+func validatePBSpecularDisplay(r *PBSpecularDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Specular) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Specular {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+	}
+	return
+}
+
+func validatePBMetallicDisplay(r *PBMetallicDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Metallic) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Metallic {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+	}
+	return
+}
+
+func validateTranslucentDisplay(r *TranslucentDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if len(r.Translucent) == 0 {
+		errs = errors.Append(errs, errors.ErrEmptyResourceProps)
+	}
+	for j, s := range r.Translucent {
+		if s.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), s, j))
+		}
+	}
+	return
+}
+
+func validatePBSpecularTexture(m *go3mf.Model, path string, r *PBSpecularTextureDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Name == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrName))
+	}
+	errs = errors.Append(errs, validateTextureRef(m, path, r.SpecularTextureID, attrSpecularTextureID))
+	errs = errors.Append(errs, validateTextureRef(m, path, r.GlossinessTextureID, attrGlossinessTexID))
+	return
+}
+
+func validatePBMetallicTexture(m *go3mf.Model, path string, r *PBMetallicTextureDisplayProperties) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.Name == "" {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrName))
+	}
+	errs = errors.Append(errs, validateTextureRef(m, path, r.MetallicTextureID, attrMetallicTextureID))
+	errs = errors.Append(errs, validateTextureRef(m, path, r.RoughnessTextureID, attrRoughnessTextureID))
+	return
+}
+
+func validateTextureRef(m *go3mf.Model, path string, id uint32, name string) error {
+	if id == 0 {
+		return errors.NewMissingFieldError(name)
+	}
+	if text, ok := m.FindAsset(path, id); ok {
+		if _, ok := text.(*Texture2D); ok {
+			return nil
+		}
+	}
+	return ErrTextureReference
+}
+
+// validateDisplayPropsRef checks that id, if defined, references
+// a textured display properties resource when textured is true
+// or a non-textured one otherwise.
+func validateDisplayPropsRef(m *go3mf.Model, path string, id uint32, textured bool) error {
+	if id == 0 {
+		return nil
+	}
+	if r, ok := m.FindAsset(path, id); ok {
+		switch r.(type) {
+		case *PBSpecularTextureDisplayProperties, *PBMetallicTextureDisplayProperties:
+			if textured {
+				return nil
+			}
+		case *PBSpecularDisplayProperties, *PBMetallicDisplayProperties, *TranslucentDisplayProperties:
+			if !textured {
+				return nil
+			}
+		}
+	}
+	return ErrDisplayPropsRef
+}
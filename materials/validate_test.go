@@ -109,6 +109,31 @@ func TestValidate(t *testing.T) {
 			fmt.Sprintf("Resources@CompositeMaterials#4: %v", ErrCompositeBase),
 			fmt.Sprintf("Resources@CompositeMaterials#5: %v", errors.ErrMissingResource),
 		}},
+		{"displayProperties", &go3mf.Model{
+			Attachments: []go3mf.Attachment{{Path: "/a.png"}},
+			Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&Texture2D{ID: 1, ContentType: TextureTypePNG, Path: "/a.png"},
+				&PBSpecularDisplayProperties{ID: 2, Specular: []PBSpecular{{Name: "a"}}},
+				&PBMetallicDisplayProperties{ID: 3, Metallic: []PBMetallic{{}}},
+				&TranslucentDisplayProperties{ID: 4},
+				&PBSpecularTextureDisplayProperties{ID: 5, Name: "a", SpecularTextureID: 1, GlossinessTextureID: 1},
+				&PBMetallicTextureDisplayProperties{ID: 6, MetallicTextureID: 2},
+				&go3mf.BaseMaterials{ID: 7, DisplayPropertiesID: 5, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{R: 1}}}},
+				&ColorGroup{ID: 8, DisplayPropertiesID: 3, Colors: []color.RGBA{{R: 1}}},
+				&Texture2DGroup{ID: 9, TextureID: 1, DisplayPropertiesID: 2, Coords: []TextureCoord{{}}},
+				&Texture2DGroup{ID: 10, TextureID: 1, DisplayPropertiesID: 5, Coords: []TextureCoord{{}}},
+				&MultiProperties{ID: 11, DisplayPropertiesID: 100, PIDs: []uint32{8}, Multis: []Multi{{PIndices: []uint32{0}}}},
+			}},
+		}, []string{
+			fmt.Sprintf("Resources@PBMetallicDisplayProperties#2@PBMetallic#0: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@TranslucentDisplayProperties#3: %v", errors.ErrEmptyResourceProps),
+			fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#5: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#5: %v", ErrTextureReference),
+			fmt.Sprintf("Resources@PBMetallicTextureDisplayProperties#5: %v", &errors.MissingFieldError{Name: attrRoughnessTextureID}),
+			fmt.Sprintf("Resources@BaseMaterials#6: %v", ErrDisplayPropsRef),
+			fmt.Sprintf("Resources@Texture2DGroup#8: %v", ErrDisplayPropsRef),
+			fmt.Sprintf("Resources@MultiProperties#10: %v", ErrDisplayPropsRef),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
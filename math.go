@@ -1,8 +1,10 @@
 package go3mf
 
 import (
-	"fmt"
 	"math"
+	"strconv"
+
+	"github.com/qmuntal/go3mf/spec"
 )
 
 type pairEntry struct {
@@ -107,8 +109,29 @@ type Matrix [16]float32
 
 // String returns the string representation of a Matrix.
 func (m1 Matrix) String() string {
-	return fmt.Sprintf("%.3f %.3f %.3f %.3f %.3f %.3f %.3f %.3f %.3f %.3f %.3f %.3f",
-		m1[0], m1[1], m1[2], m1[4], m1[5], m1[6], m1[8], m1[9], m1[10], m1[12], m1[13], m1[14])
+	return m1.Format(3)
+}
+
+// Format returns the 3MF transform representation of m1,
+// which omits the last column, formatting each value with prec decimals.
+// The special precision -1 uses the smallest number of digits
+// necessary to represent the values exactly.
+func (m1 Matrix) Format(prec int) string {
+	var b []byte
+	for i, idx := range [...]int{0, 1, 2, 4, 5, 6, 8, 9, 10, 12, 13, 14} {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendFloat(b, float64(m1[idx]), 'f', prec, 32)
+	}
+	return string(b)
+}
+
+// ParseMatrix parses a 3MF transform string,
+// which contains the 12 values of the first three columns of the matrix.
+func ParseMatrix(s string) (Matrix, bool) {
+	m, ok := spec.ParseMatrix(s)
+	return Matrix(m), ok
 }
 
 // Identity returns the 4x4 identity matrix.
@@ -150,6 +173,219 @@ func (m1 Matrix) Mul(m2 Matrix) Matrix {
 	}
 }
 
+// Scale returns a matrix with a relative scale applied.
+func (m1 Matrix) Scale(x, y, z float32) Matrix {
+	return Matrix{x, 0, 0, 0, 0, y, 0, 0, 0, 0, z, 0, 0, 0, 0, 1}.Mul(m1)
+}
+
+// RotationAxisAngle returns a matrix that rotates angle radians
+// around axis, following the right hand rule.
+// The identity is returned if axis is zero.
+func RotationAxisAngle(axis Point3D, angle float32) Matrix {
+	x, y, z := float64(axis[0]), float64(axis[1]), float64(axis[2])
+	n := math.Sqrt(x*x + y*y + z*z)
+	if n == 0 {
+		return Identity()
+	}
+	x, y, z = x/n, y/n, z/n
+	s, c := math.Sincos(float64(angle))
+	t := 1 - c
+	return newLinearMatrix([3][3]float64{
+		{t*x*x + c, t*x*y - s*z, t*x*z + s*y},
+		{t*x*y + s*z, t*y*y + c, t*y*z - s*x},
+		{t*x*z - s*y, t*y*z + s*x, t*z*z + c},
+	})
+}
+
+// RotationEuler returns a matrix that rotates x radians around the X axis,
+// then y radians around the Y axis and then z radians around the Z axis.
+func RotationEuler(x, y, z float32) Matrix {
+	return RotationAxisAngle(Point3D{0, 0, 1}, z).
+		Mul(RotationAxisAngle(Point3D{0, 1, 0}, y)).
+		Mul(RotationAxisAngle(Point3D{1, 0, 0}, x))
+}
+
+// Quaternion defines a rotation as an array of 4 components: x, y, z and w.
+type Quaternion [4]float32
+
+// RotationQuaternion returns a matrix that applies the rotation defined by q.
+// q is normalized before being used and the identity is returned if it is zero.
+func RotationQuaternion(q Quaternion) Matrix {
+	x, y, z, w := float64(q[0]), float64(q[1]), float64(q[2]), float64(q[3])
+	n := math.Sqrt(x*x + y*y + z*z + w*w)
+	if n == 0 {
+		return Identity()
+	}
+	x, y, z, w = x/n, y/n, z/n, w/n
+	return newLinearMatrix([3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	})
+}
+
+// newLinearMatrix returns a matrix that applies the linear transform a,
+// where a[r][c] is the element in the r'th row and c'th column when
+// transforming column vectors.
+func newLinearMatrix(a [3][3]float64) Matrix {
+	var m Matrix
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			m[4*c+r] = float32(a[r][c])
+		}
+	}
+	m[15] = 1
+	return m
+}
+
+// Determinant returns the determinant of the matrix.
+// A negative determinant means that the transform mirrors the objects.
+func (m1 Matrix) Determinant() float32 {
+	var m [16]float64
+	for i, v := range m1 {
+		m[i] = float64(v)
+	}
+	b := cofactorTerms(m)
+	return float32(b[0]*b[11] - b[1]*b[10] + b[2]*b[9] + b[3]*b[8] - b[4]*b[7] + b[5]*b[6])
+}
+
+// Inverse returns the inverse of the matrix.
+// It returns false if the matrix is singular.
+func (m1 Matrix) Inverse() (Matrix, bool) {
+	var m [16]float64
+	for i, v := range m1 {
+		m[i] = float64(v)
+	}
+	b := cofactorTerms(m)
+	det := b[0]*b[11] - b[1]*b[10] + b[2]*b[9] + b[3]*b[8] - b[4]*b[7] + b[5]*b[6]
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return Matrix{}, false
+	}
+	inv := [16]float64{
+		m[5]*b[11] - m[6]*b[10] + m[7]*b[9],
+		m[2]*b[10] - m[1]*b[11] - m[3]*b[9],
+		m[13]*b[5] - m[14]*b[4] + m[15]*b[3],
+		m[10]*b[4] - m[9]*b[5] - m[11]*b[3],
+		m[6]*b[8] - m[4]*b[11] - m[7]*b[7],
+		m[0]*b[11] - m[2]*b[8] + m[3]*b[7],
+		m[14]*b[2] - m[12]*b[5] - m[15]*b[1],
+		m[8]*b[5] - m[10]*b[2] + m[11]*b[1],
+		m[4]*b[10] - m[5]*b[8] + m[7]*b[6],
+		m[1]*b[8] - m[0]*b[10] - m[3]*b[6],
+		m[12]*b[4] - m[13]*b[2] + m[15]*b[0],
+		m[9]*b[2] - m[8]*b[4] - m[11]*b[0],
+		m[5]*b[7] - m[4]*b[9] - m[6]*b[6],
+		m[0]*b[9] - m[1]*b[7] + m[2]*b[6],
+		m[13]*b[1] - m[12]*b[3] - m[14]*b[0],
+		m[8]*b[3] - m[9]*b[1] + m[10]*b[0],
+	}
+	var r Matrix
+	for i, v := range inv {
+		r[i] = float32(v / det)
+	}
+	return r, true
+}
+
+// cofactorTerms returns the 2x2 determinants shared
+// by the computation of the determinant and the inverse.
+func cofactorTerms(m [16]float64) [12]float64 {
+	return [12]float64{
+		m[0]*m[5] - m[1]*m[4],
+		m[0]*m[6] - m[2]*m[4],
+		m[0]*m[7] - m[3]*m[4],
+		m[1]*m[6] - m[2]*m[5],
+		m[1]*m[7] - m[3]*m[5],
+		m[2]*m[7] - m[3]*m[6],
+		m[8]*m[13] - m[9]*m[12],
+		m[8]*m[14] - m[10]*m[12],
+		m[8]*m[15] - m[11]*m[12],
+		m[9]*m[14] - m[10]*m[13],
+		m[9]*m[15] - m[11]*m[13],
+		m[10]*m[15] - m[11]*m[14],
+	}
+}
+
+// IsAffine returns true if the last column of the matrix is (0, 0, 0, 1),
+// which is required to encode it as a 3MF transform.
+func (m1 Matrix) IsAffine() bool {
+	return m1[3] == 0 && m1[7] == 0 && m1[11] == 0 && m1[15] == 1
+}
+
+// IsValidTransform returns true if the matrix can be used as a 3MF transform:
+// it is affine, all its values are finite and it is not singular.
+// The zero matrix is valid as it means that no transform is defined.
+func (m1 Matrix) IsValidTransform() bool {
+	if m1 == (Matrix{}) {
+		return true
+	}
+	for _, v := range m1 {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return false
+		}
+	}
+	return m1.IsAffine() && m1.Determinant() != 0
+}
+
+// Decompose splits an affine matrix into a translation, a rotation and a scale
+// such that the matrix is equivalent to scaling, then rotating and then translating.
+// A mirroring transform is decomposed with a negative X scale.
+// It returns false if the matrix is not affine or is singular.
+// Shear can not be represented, so the result is only exact when the matrix does not contain it.
+func (m1 Matrix) Decompose() (translation Point3D, rotation Quaternion, scale Point3D, ok bool) {
+	if !m1.IsAffine() || m1.Determinant() == 0 {
+		return
+	}
+	translation = Point3D{m1[12], m1[13], m1[14]}
+	var a [3][3]float64
+	for c := 0; c < 3; c++ {
+		v := [3]float64{float64(m1[4*c]), float64(m1[4*c+1]), float64(m1[4*c+2])}
+		n := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+		if c == 0 && m1.Determinant() < 0 {
+			n = -n
+		}
+		scale[c] = float32(n)
+		for r := 0; r < 3; r++ {
+			a[r][c] = v[r] / n
+		}
+	}
+	rotation = quaternionFromRotation(a)
+	ok = true
+	return
+}
+
+// quaternionFromRotation returns the unit quaternion
+// equivalent to the rotation matrix a.
+func quaternionFromRotation(a [3][3]float64) Quaternion {
+	var x, y, z, w float64
+	switch trace := a[0][0] + a[1][1] + a[2][2]; {
+	case trace > 0:
+		s := 0.5 / math.Sqrt(trace+1)
+		w = 0.25 / s
+		x = (a[2][1] - a[1][2]) * s
+		y = (a[0][2] - a[2][0]) * s
+		z = (a[1][0] - a[0][1]) * s
+	case a[0][0] > a[1][1] && a[0][0] > a[2][2]:
+		s := 2 * math.Sqrt(1+a[0][0]-a[1][1]-a[2][2])
+		w = (a[2][1] - a[1][2]) / s
+		x = 0.25 * s
+		y = (a[0][1] + a[1][0]) / s
+		z = (a[0][2] + a[2][0]) / s
+	case a[1][1] > a[2][2]:
+		s := 2 * math.Sqrt(1+a[1][1]-a[0][0]-a[2][2])
+		w = (a[0][2] - a[2][0]) / s
+		x = (a[0][1] + a[1][0]) / s
+		y = 0.25 * s
+		z = (a[1][2] + a[2][1]) / s
+	default:
+		s := 2 * math.Sqrt(1+a[2][2]-a[0][0]-a[1][1])
+		w = (a[1][0] - a[0][1]) / s
+		x = (a[0][2] + a[2][0]) / s
+		y = (a[1][2] + a[2][1]) / s
+		z = 0.25 * s
+	}
+	return Quaternion{float32(x), float32(y), float32(z), float32(w)}
+}
+
 // Mul3D performs a "matrix product" between this matrix
 // and another 3D point.
 func (m1 Matrix) Mul3D(v Point3D) Point3D {
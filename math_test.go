@@ -1,6 +1,7 @@
 package go3mf
 
 import (
+	"math"
 	"reflect"
 	"testing"
 )
@@ -374,3 +375,185 @@ func TestMatrix_MulBox(t *testing.T) {
 		})
 	}
 }
+
+func matrixNear(a, b Matrix) bool {
+	for i := range a {
+		if math.Abs(float64(a[i]-b[i])) > 1e-5 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatrix_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		prec int
+		want string
+	}{
+		{"identity", Identity(), 0, "1 0 0 0 1 0 0 0 1 0 0 0"},
+		{"shortest", Identity().Translate(0.125, -2, 1e-3), -1, "1 0 0 0 1 0 0 0 1 0.125 -2 0.001"},
+		{"prec", Identity().Translate(0.125, 0, 0), 2, "1.00 0.00 0.00 0.00 1.00 0.00 0.00 0.00 1.00 0.12 0.00 0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.Format(tt.prec)
+			if got != tt.want {
+				t.Errorf("Matrix.Format() = %v, want %v", got, tt.want)
+			}
+			if tt.prec == -1 {
+				if m, ok := ParseMatrix(got); !ok || m != tt.m {
+					t.Errorf("ParseMatrix() = %v, %v, want %v", m, ok, tt.m)
+				}
+			}
+		})
+	}
+	if _, ok := ParseMatrix("1 0 0"); ok {
+		t.Error("ParseMatrix() expected to fail")
+	}
+}
+
+func TestMatrix_Scale(t *testing.T) {
+	got := Identity().Translate(1, 2, 3).Scale(2, 3, 4)
+	want := Matrix{2, 0, 0, 0, 0, 3, 0, 0, 0, 0, 4, 0, 2, 6, 12, 1}
+	if got != want {
+		t.Errorf("Matrix.Scale() = %v, want %v", got, want)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	half := float32(math.Sqrt2 / 2)
+	tests := []struct {
+		name string
+		m    Matrix
+		v    Point3D
+		want Point3D
+	}{
+		{"axis-z", RotationAxisAngle(Point3D{0, 0, 2}, math.Pi/2), Point3D{1, 0, 0}, Point3D{0, 1, 0}},
+		{"axis-x", RotationAxisAngle(Point3D{1, 0, 0}, math.Pi/2), Point3D{0, 1, 0}, Point3D{0, 0, 1}},
+		{"axis-zero", RotationAxisAngle(Point3D{}, math.Pi/2), Point3D{1, 2, 3}, Point3D{1, 2, 3}},
+		{"euler", RotationEuler(math.Pi/2, 0, math.Pi/2), Point3D{0, 1, 0}, Point3D{0, 0, 1}},
+		{"euler-order", RotationEuler(math.Pi/2, math.Pi/2, 0), Point3D{0, 1, 0}, Point3D{1, 0, 0}},
+		{"quaternion", RotationQuaternion(Quaternion{0, 0, half, half}), Point3D{1, 0, 0}, Point3D{0, 1, 0}},
+		{"quaternion-unnormalized", RotationQuaternion(Quaternion{0, 0, 2, 2}), Point3D{1, 0, 0}, Point3D{0, 1, 0}},
+		{"quaternion-zero", RotationQuaternion(Quaternion{}), Point3D{1, 2, 3}, Point3D{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.Mul3D(tt.v)
+			for i := range got {
+				if math.Abs(float64(got[i]-tt.want[i])) > 1e-6 {
+					t.Errorf("Matrix.Mul3D() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix_Inverse(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      Matrix
+		wantOk bool
+	}{
+		{"identity", Identity(), true},
+		{"affine", RotationEuler(0.3, 0.2, 0.1).Scale(2, -1, 3).Translate(1, 2, 3), true},
+		{"projective", Matrix{1, 0, 0, 0.5, 0, 2, 0, 0, 0, 0, 1, 0, 1, 2, 3, 1}, true},
+		{"singular", Identity().Scale(1, 0, 1), false},
+		{"zero", Matrix{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.m.Inverse()
+			if ok != tt.wantOk {
+				t.Fatalf("Matrix.Inverse() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && !matrixNear(tt.m.Mul(got), Identity()) {
+				t.Errorf("Matrix.Inverse() = %v", got)
+			}
+		})
+	}
+}
+
+func TestMatrix_Determinant(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matrix
+		want float32
+	}{
+		{"identity", Identity(), 1},
+		{"scale", Identity().Scale(2, 3, 4).Translate(5, 6, 7), 24},
+		{"mirror", Identity().Scale(-1, 1, 1), -1},
+		{"rotation", RotationAxisAngle(Point3D{1, 1, 0}, 1), 1},
+		{"zero", Matrix{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Determinant(); math.Abs(float64(got-tt.want)) > 1e-5 {
+				t.Errorf("Matrix.Determinant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrix_IsValidTransform(t *testing.T) {
+	nan := float32(math.NaN())
+	tests := []struct {
+		name       string
+		m          Matrix
+		wantAffine bool
+		want       bool
+	}{
+		{"zero", Matrix{}, false, true},
+		{"identity", Identity(), true, true},
+		{"projective", Matrix{1, 0, 0, 0.5, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}, false, false},
+		{"singular", Identity().Scale(0, 1, 1), true, false},
+		{"nan", Identity().Translate(nan, 0, 0), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.IsAffine(); got != tt.wantAffine {
+				t.Errorf("Matrix.IsAffine() = %v, want %v", got, tt.wantAffine)
+			}
+			if got := tt.m.IsValidTransform(); got != tt.want {
+				t.Errorf("Matrix.IsValidTransform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrix_Decompose(t *testing.T) {
+	tests := []struct {
+		name  string
+		t     Point3D
+		r     Quaternion
+		s     Point3D
+		valid bool
+	}{
+		{"identity", Point3D{}, Quaternion{0, 0, 0, 1}, Point3D{1, 1, 1}, true},
+		{"rotation-z", Point3D{1, 2, 3}, Quaternion{0, 0, 0.5, 0.8660254}, Point3D{2, 3, 4}, true},
+		{"rotation-x", Point3D{}, Quaternion{0.9238795, 0, 0, 0.3826834}, Point3D{1, 1, 1}, true},
+		{"mirror", Point3D{}, Quaternion{0, 0.6, 0, 0.8}, Point3D{-2, 1, 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := RotationQuaternion(tt.r).Mul(Identity().Scale(tt.s[0], tt.s[1], tt.s[2])).Translate(tt.t[0], tt.t[1], tt.t[2])
+			tr, r, s, ok := m.Decompose()
+			if !ok {
+				t.Fatal("Matrix.Decompose() not ok")
+			}
+			got := RotationQuaternion(r).Mul(Identity().Scale(s[0], s[1], s[2])).Translate(tr[0], tr[1], tr[2])
+			if !matrixNear(got, m) {
+				t.Errorf("Matrix.Decompose() = %v %v %v, want %v", tr, r, s, m)
+			}
+			if tr != tt.t {
+				t.Errorf("Matrix.Decompose() translation = %v, want %v", tr, tt.t)
+			}
+		})
+	}
+	if _, _, _, ok := Identity().Scale(0, 1, 1).Decompose(); ok {
+		t.Error("Matrix.Decompose() expected to fail for singular matrices")
+	}
+}
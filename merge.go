@@ -0,0 +1,302 @@
+package go3mf
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+// Merge adds the resources, build items, child models, attachments
+// and relationships of src to dst. src is not modified.
+//
+// The resources of the src root model are given new IDs using dst.Resources.UnusedID
+// and every reference to them is rewritten, including extension values that
+// implement spec.IDRemapper. Assets that do not implement spec.IDRemapper keep their ID,
+// so an error is returned and dst is not modified if that ID is already used in dst.
+// Child models and attachments whose path is already used in dst are renamed
+// and every reference to them is rewritten, including extension values that
+// implement spec.PathRemapper. Triangles provided by a mesh source are not rewritten.
+//
+// The model attributes, metadata and extension values of dst take precedence over the src ones,
+// so the src thumbnail is only kept if dst does not have one.
+func Merge(dst, src *Model) error {
+	src = src.Clone()
+	paths := newPathMapper(dst, src)
+	ids, err := newIDMapper(&dst.Resources, &src.Resources)
+	if err != nil {
+		return err
+	}
+	srcRoot := src.PathOrDefault()
+	isRoot := func(p string) bool { return p == "" || p == srcRoot }
+	partIDs := func(p string, id uint32) uint32 {
+		if isRoot(p) {
+			return ids.remap(id)
+		}
+		return id
+	}
+
+	for _, a := range src.Resources.Assets {
+		remapExtension(a, ids.remap, paths.remap, partIDs)
+		dst.Resources.Assets = append(dst.Resources.Assets, a)
+	}
+	for _, o := range src.Resources.Objects {
+		o.remap(ids.remap, paths.remap, partIDs)
+		dst.Resources.Objects = append(dst.Resources.Objects, o)
+	}
+	for _, item := range src.Build.Items {
+		if isRoot(item.ObjectPath()) {
+			item.ObjectID = ids.remap(item.ObjectID)
+		}
+		item.AnyAttr.remap(ids.noop, paths.remap, partIDs)
+		dst.Build.Items = append(dst.Build.Items, item)
+	}
+	for _, p := range src.childPaths() {
+		child := src.Childs[p]
+		for _, a := range child.Resources.Assets {
+			remapExtension(a, ids.noop, paths.remap, ids.noopPart)
+		}
+		for _, o := range child.Resources.Objects {
+			o.remap(ids.noop, paths.remap, ids.noopPart)
+		}
+		child.Resources.AnyAttr.remap(ids.noop, paths.remap, ids.noopPart)
+		child.Any.remap(ids.noop, paths.remap, ids.noopPart)
+		child.Relationships = remapRelationships(child.Relationships, paths.remap)
+		if dst.Childs == nil {
+			dst.Childs = make(map[string]*ChildModel)
+		}
+		dst.Childs[paths.remap(p)] = child
+	}
+	for _, a := range src.Attachments {
+		a.Path = paths.remap(a.Path)
+		dst.Attachments = append(dst.Attachments, a)
+	}
+	dst.Relationships = appendRelationships(dst.Relationships, remapRelationships(src.Relationships, paths.remap))
+	dst.RootRelationships = appendRelationships(dst.RootRelationships, remapRelationships(src.RootRelationships, paths.remap))
+	dst.Extensions = appendExtensions(dst.Extensions, src.Extensions)
+	dst.Metadata = appendMetadata(dst.Metadata, src.Metadata)
+	if dst.Thumbnail == "" {
+		dst.Thumbnail = paths.remap(src.Thumbnail)
+	}
+	return nil
+}
+
+type idMapper map[uint32]uint32
+
+// newIDMapper assigns an unused ID in dst to every resource in src.
+// The assets that do not implement spec.IDRemapper keep their ID,
+// so they are reserved first and fail if their ID is used in dst.
+func newIDMapper(dst, src *Resources) (idMapper, error) {
+	ids := make(idMapper)
+	used := Resources{
+		Assets:  append([]Asset(nil), dst.Assets...),
+		Objects: append([]*Object(nil), dst.Objects...),
+	}
+	for i, a := range src.Assets {
+		if _, ok := a.(spec.IDRemapper); ok {
+			continue
+		}
+		id := a.Identify()
+		_, isAsset := used.FindAsset(id)
+		_, isObject := used.FindObject(id)
+		if isAsset || isObject {
+			return nil, errors.WrapIndex(errors.ErrDuplicatedID, a, i)
+		}
+		ids[id] = id
+		used.Assets = append(used.Assets, a)
+	}
+	reserve := func(old uint32) {
+		id := used.UnusedID()
+		ids[old] = id
+		used.Objects = append(used.Objects, &Object{ID: id})
+	}
+	for _, a := range src.Assets {
+		if _, ok := a.(spec.IDRemapper); ok {
+			reserve(a.Identify())
+		}
+	}
+	for _, o := range src.Objects {
+		reserve(o.ID)
+	}
+	return ids, nil
+}
+
+func (m idMapper) remap(id uint32) uint32 {
+	if n, ok := m[id]; ok {
+		return n
+	}
+	return id
+}
+
+func (idMapper) noop(id uint32) uint32 {
+	return id
+}
+
+func (idMapper) noopPart(_ string, id uint32) uint32 {
+	return id
+}
+
+type pathMapper map[string]string
+
+// newPathMapper maps the src root model to the dst root model
+// and renames the src child models and attachments already used in dst.
+func newPathMapper(dst, src *Model) pathMapper {
+	paths := pathMapper{src.PathOrDefault(): dst.PathOrDefault()}
+	used := map[string]struct{}{strings.ToLower(dst.PathOrDefault()): {}}
+	for p := range dst.Childs {
+		used[strings.ToLower(p)] = struct{}{}
+	}
+	for _, a := range dst.Attachments {
+		used[strings.ToLower(a.Path)] = struct{}{}
+	}
+	rename := func(name string) {
+		if _, ok := paths[name]; ok {
+			return
+		}
+		newName := uniquePartName(name, used)
+		used[strings.ToLower(newName)] = struct{}{}
+		paths[name] = newName
+	}
+	for _, p := range src.childPaths() {
+		rename(p)
+	}
+	for _, a := range src.Attachments {
+		rename(a.Path)
+	}
+	return paths
+}
+
+func (m pathMapper) remap(p string) string {
+	if n, ok := m[p]; ok {
+		return n
+	}
+	return p
+}
+
+// uniquePartName returns name if it is not used,
+// else it appends the lowest numeric suffix that makes it unique.
+func uniquePartName(name string, used map[string]struct{}) string {
+	if _, ok := used[strings.ToLower(name)]; !ok {
+		return name
+	}
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		n := fmt.Sprintf("%s_%d%s", base, i, ext)
+		if _, ok := used[strings.ToLower(n)]; !ok {
+			return n
+		}
+	}
+}
+
+// remap rewrites the references of o. References to resources of other model parts,
+// such as component objects, are rewritten with partIDs, which receives
+// the referenced path, empty if not defined.
+func (o *Object) remap(ids func(uint32) uint32, paths func(string) string, partIDs func(string, uint32) uint32) {
+	o.ID = ids(o.ID)
+	o.PID = ids(o.PID)
+	o.Thumbnail = paths(o.Thumbnail)
+	o.AnyAttr.remap(ids, paths, partIDs)
+	o.Any.remap(ids, paths, partIDs)
+	if o.Mesh != nil {
+		for i := range o.Mesh.Triangles {
+			t := &o.Mesh.Triangles[i]
+			t.SetPID(ids(t.PID()))
+		}
+		o.Mesh.AnyAttr.remap(ids, paths, partIDs)
+		o.Mesh.Any.remap(ids, paths, partIDs)
+	}
+	for _, c := range o.Components {
+		c.ObjectID = partIDs(c.ObjectPath(""), c.ObjectID)
+		c.AnyAttr.remap(ids, paths, partIDs)
+	}
+}
+
+func (e AnyAttr) remap(ids func(uint32) uint32, paths func(string) string, partIDs func(string, uint32) uint32) {
+	for _, a := range e {
+		remapExtension(a, ids, paths, partIDs)
+	}
+}
+
+func (e Any) remap(ids func(uint32) uint32, paths func(string) string, partIDs func(string, uint32) uint32) {
+	for _, a := range e {
+		remapExtension(a, ids, paths, partIDs)
+	}
+}
+
+// remapExtension rewrites the references of v. Part IDs are rewritten
+// before the paths, so partIDs receives the original paths.
+func remapExtension(v interface{}, ids func(uint32) uint32, paths func(string) string, partIDs func(string, uint32) uint32) {
+	if r, ok := v.(spec.IDRemapper); ok {
+		r.RemapIDs(ids)
+	}
+	if r, ok := v.(spec.PartIDRemapper); ok {
+		r.RemapPartIDs(partIDs)
+	}
+	if r, ok := v.(spec.PathRemapper); ok {
+		r.RemapPaths(paths)
+	}
+}
+
+// RemapIDs replaces the resource IDs with the ones returned by fn.
+func (r *BaseMaterials) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+	r.DisplayPropertiesID = fn(r.DisplayPropertiesID)
+}
+
+func remapRelationships(rels []Relationship, fn func(string) string) []Relationship {
+	for i := range rels {
+		rels[i].Path = fn(rels[i].Path)
+	}
+	return rels
+}
+
+func appendRelationships(dst, src []Relationship) []Relationship {
+	for _, r := range src {
+		var found bool
+		for _, ro := range dst {
+			if ro.Type == r.Type && strings.EqualFold(ro.Path, r.Path) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, r)
+		}
+	}
+	return dst
+}
+
+func appendExtensions(dst, src []Extension) []Extension {
+	for _, e := range src {
+		var found bool
+		for _, eo := range dst {
+			if eo.Namespace == e.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
+
+func appendMetadata(dst, src []Metadata) []Metadata {
+	for _, m := range src {
+		var found bool
+		for _, mo := range dst {
+			if mo.Name == m.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, m)
+		}
+	}
+	return dst
+}
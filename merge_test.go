@@ -0,0 +1,135 @@
+package go3mf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func (f *fakeAttr) RemapPaths(fn func(string) string) {
+	f.Value = fn(f.Value)
+}
+
+func TestMerge(t *testing.T) {
+	dst := &Model{
+		Metadata: []Metadata{{Name: xml.Name{Local: "Title"}, Value: "dst"}},
+		Resources: Resources{
+			Assets:  []Asset{&BaseMaterials{ID: 1}},
+			Objects: []*Object{{ID: 2, Mesh: &Mesh{}}},
+		},
+		Build:       Build{Items: []*Item{{ObjectID: 2}}},
+		Childs:      map[string]*ChildModel{"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1}}}}},
+		Attachments: []Attachment{{Path: "/3D/Textures/a.png", ContentType: "image/png", Stream: bytes.NewBufferString("dst")}},
+		Relationships: []Relationship{
+			{Path: "/3D/Textures/a.png", Type: "texture"},
+		},
+	}
+	src := &Model{
+		Path:       "/3D/src.model",
+		Thumbnail:  "/3D/Textures/b.png",
+		Extensions: []Extension{{Namespace: fakeExtension, LocalName: "qm"}},
+		Metadata: []Metadata{
+			{Name: xml.Name{Local: "Title"}, Value: "src"},
+			{Name: xml.Name{Local: "Designer"}, Value: "src"},
+		},
+		Resources: Resources{
+			Assets: []Asset{&BaseMaterials{ID: 1, DisplayPropertiesID: 5}, &fakeAsset{ID: 5}},
+			Objects: []*Object{
+				{ID: 2, PID: 1, Thumbnail: "/3D/Textures/A.png", Mesh: &Mesh{Triangles: []Triangle{NewTrianglePID(0, 1, 2, 1, 0, 0, 0)}}},
+				{ID: 3, Components: []*Component{
+					{ObjectID: 2},
+					{ObjectID: 2, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/src.model"}}},
+					{ObjectID: 1, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/other.model"}}},
+				}},
+			},
+		},
+		Build:  Build{Items: []*Item{{ObjectID: 3}, {ObjectID: 1, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/other.model"}}}}},
+		Childs: map[string]*ChildModel{"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1, Name: "src"}}}}},
+		Attachments: []Attachment{
+			{Path: "/3D/Textures/A.png", ContentType: "image/png", Stream: bytes.NewBufferString("src")},
+			{Path: "/3D/Textures/b.png", ContentType: "image/png", Stream: bytes.NewBufferString("b")},
+		},
+		Relationships: []Relationship{
+			{Path: "/3D/Textures/A.png", Type: "texture"},
+			{Path: "/3D/Textures/b.png", Type: "texture"},
+		},
+	}
+	want := &Model{
+		Thumbnail:  "/3D/Textures/b.png",
+		Extensions: []Extension{{Namespace: fakeExtension, LocalName: "qm"}},
+		Metadata: []Metadata{
+			{Name: xml.Name{Local: "Title"}, Value: "dst"},
+			{Name: xml.Name{Local: "Designer"}, Value: "src"},
+		},
+		Resources: Resources{
+			Assets: []Asset{&BaseMaterials{ID: 1}, &BaseMaterials{ID: 3, DisplayPropertiesID: 5}, &fakeAsset{ID: 5}},
+			Objects: []*Object{
+				{ID: 2, Mesh: &Mesh{}},
+				{ID: 4, PID: 3, Thumbnail: "/3D/Textures/A_1.png", Mesh: &Mesh{Triangles: []Triangle{NewTrianglePID(0, 1, 2, 3, 0, 0, 0)}}},
+				{ID: 6, Components: []*Component{
+					{ObjectID: 4},
+					{ObjectID: 4, AnyAttr: AnyAttr{&fakeAttr{Value: DefaultModelPath}}},
+					{ObjectID: 1, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/other_1.model"}}},
+				}},
+			},
+		},
+		Build: Build{Items: []*Item{
+			{ObjectID: 2}, {ObjectID: 6}, {ObjectID: 1, AnyAttr: AnyAttr{&fakeAttr{Value: "/3D/other_1.model"}}},
+		}},
+		Childs: map[string]*ChildModel{
+			"/3D/other.model":   {Resources: Resources{Objects: []*Object{{ID: 1}}}},
+			"/3D/other_1.model": {Resources: Resources{Objects: []*Object{{ID: 1, Name: "src"}}}},
+		},
+		Attachments: []Attachment{
+			{Path: "/3D/Textures/a.png", ContentType: "image/png", Stream: bytes.NewBufferString("dst")},
+			{Path: "/3D/Textures/A_1.png", ContentType: "image/png", Stream: bytes.NewBufferString("src")},
+			{Path: "/3D/Textures/b.png", ContentType: "image/png", Stream: bytes.NewBufferString("b")},
+		},
+		Relationships: []Relationship{
+			{Path: "/3D/Textures/a.png", Type: "texture"},
+			{Path: "/3D/Textures/A_1.png", Type: "texture"},
+			{Path: "/3D/Textures/b.png", Type: "texture"},
+		},
+	}
+	srcCopy := src.Clone()
+	if err := Merge(dst, src); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if diff := deep.Equal(dst, want); diff != nil {
+		t.Errorf("Merge() = %v", diff)
+	}
+	if diff := deep.Equal(src, srcCopy); diff != nil {
+		t.Errorf("Merge() modified src = %v", diff)
+	}
+}
+
+func TestMerge_FixedIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		dst     *Model
+		src     *Model
+		want    []Asset
+		wantErr bool
+	}{
+		{"reservedFirst", &Model{Resources: Resources{Objects: []*Object{{ID: 1}}}},
+			&Model{Resources: Resources{Assets: []Asset{&BaseMaterials{ID: 1}, &fakeAsset{ID: 2}}}},
+			[]Asset{&BaseMaterials{ID: 3}, &fakeAsset{ID: 2}}, false},
+		{"collidesObject", &Model{Resources: Resources{Objects: []*Object{{ID: 2}}}},
+			&Model{Resources: Resources{Assets: []Asset{&fakeAsset{ID: 2}}}}, nil, true},
+		{"collidesAsset", &Model{Resources: Resources{Assets: []Asset{&BaseMaterials{ID: 2}}}},
+			&Model{Resources: Resources{Assets: []Asset{&fakeAsset{ID: 2}}}}, []Asset{&BaseMaterials{ID: 2}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Merge(tt.dst, tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Merge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := deep.Equal(tt.dst.Resources.Assets, tt.want); diff != nil {
+				t.Errorf("Merge() = %v", diff)
+			}
+		})
+	}
+}
@@ -25,22 +25,37 @@ func (o *opcPart) AddRelationship(r Relationship) {
 }
 
 type opcWriter struct {
-	w *opc.Writer
+	w   *opc.Writer
+	out io.Writer
 }
 
 func newOpcWriter(w io.Writer) *opcWriter {
-	return &opcWriter{opc.NewWriter(w)}
+	return &opcWriter{w: opc.NewWriter(w), out: w}
 }
 
-func (o *opcWriter) Create(name, contentType string) (packagePart, error) {
+func (o *opcWriter) Create(name, contentType string, c Compression) (packagePart, error) {
 	p := &opc.Part{Name: opc.NormalizePartName(name), ContentType: contentType}
-	w, err := o.w.CreatePart(p, opc.CompressionNormal)
+	w, err := o.w.CreatePart(p, opcCompression(c))
 	if err != nil {
 		return nil, err
 	}
 	return &opcPart{Writer: w, Part: p}, nil
 }
 
+func opcCompression(c Compression) opc.CompressionOption {
+	switch c {
+	case CompressionNone:
+		return opc.CompressionNone
+	case CompressionMaximum:
+		return opc.CompressionMaximum
+	case CompressionFast:
+		return opc.CompressionFast
+	case CompressionSuperFast:
+		return opc.CompressionSuperFast
+	}
+	return opc.CompressionNormal
+}
+
 func (o *opcWriter) AddRelationship(r Relationship) {
 	for _, ro := range o.w.Relationships {
 		if ro.Type == r.Type && ro.TargetURI == r.Path {
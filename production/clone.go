@@ -0,0 +1,25 @@
+package production
+
+// Clone returns a deep copy of u.
+func (u *BuildAttr) Clone() interface{} {
+	c := *u
+	return &c
+}
+
+// Clone returns a deep copy of u.
+func (u *ObjectAttr) Clone() interface{} {
+	c := *u
+	return &c
+}
+
+// Clone returns a deep copy of p.
+func (p *ItemAttr) Clone() interface{} {
+	c := *p
+	return &c
+}
+
+// Clone returns a deep copy of p.
+func (p *ComponentAttr) Clone() interface{} {
+	c := *p
+	return &c
+}
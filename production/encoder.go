@@ -22,16 +22,19 @@ func (u *ObjectAttr) Marshal3MFAttr(_ spec.Encoder) ([]xml.Attr, error) {
 
 // Marshal3MFAttr encodes the resource attributes.
 func (p *ItemAttr) Marshal3MFAttr(_ spec.Encoder) ([]xml.Attr, error) {
-	return []xml.Attr{
-		{Name: xml.Name{Space: Namespace, Local: attrPath}, Value: p.Path},
-		{Name: xml.Name{Space: Namespace, Local: attrProdUUID}, Value: p.UUID},
-	}, nil
+	return marshalPathUUID(p.Path, p.UUID), nil
 }
 
 // Marshal3MFAttr encodes the resource attributes.
 func (p *ComponentAttr) Marshal3MFAttr(_ spec.Encoder) ([]xml.Attr, error) {
-	return []xml.Attr{
-		{Name: xml.Name{Space: Namespace, Local: attrPath}, Value: p.Path},
-		{Name: xml.Name{Space: Namespace, Local: attrProdUUID}, Value: p.UUID},
-	}, nil
+	return marshalPathUUID(p.Path, p.UUID), nil
+}
+
+// marshalPathUUID omits the path attribute when it references the root model.
+func marshalPathUUID(path, uuid string) []xml.Attr {
+	attrs := make([]xml.Attr, 0, 2)
+	if path != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Space: Namespace, Local: attrPath}, Value: path})
+	}
+	return append(attrs, xml.Attr{Name: xml.Name{Space: Namespace, Local: attrProdUUID}, Value: uuid})
 }
@@ -1,6 +1,7 @@
 package production
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/qmuntal/go3mf"
@@ -72,3 +73,60 @@ func TestSetMissingUUIDs(t *testing.T) {
 		t.Errorf("SetMissingUUIDs() should have filled object attrs")
 	}
 }
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"build", func() spec.Cloner { return &BuildAttr{UUID: "a"} }, func(c interface{}) { c.(*BuildAttr).UUID = "b" }},
+		{"object", func() spec.Cloner { return &ObjectAttr{UUID: "b"} }, func(c interface{}) { c.(*ObjectAttr).UUID = "c" }},
+		{"item", func() spec.Cloner {
+			return &ItemAttr{UUID: "c", Path: "/3D/other.model"}
+		}, func(c interface{}) { c.(*ItemAttr).Path = "/3D/new.model" }},
+		{"component", func() spec.Cloner {
+			return &ComponentAttr{UUID: "d", Path: "/3D/other.model"}
+		}, func(c interface{}) { c.(*ComponentAttr).Path = "/3D/new.model" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"item", &ItemAttr{UUID: "a", Path: "/a.model"}, &ItemAttr{UUID: "a", Path: "/a.model_1"}},
+		{"itemEmpty", &ItemAttr{UUID: "a"}, &ItemAttr{UUID: "a"}},
+		{"component", &ComponentAttr{UUID: "b", Path: "/a.model"}, &ComponentAttr{UUID: "b", Path: "/a.model_1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package production
+
+// RemapPaths replaces the object path with the one returned by fn.
+func (p *ItemAttr) RemapPaths(fn func(string) string) {
+	if p.Path != "" {
+		p.Path = fn(p.Path)
+	}
+}
+
+// RemapPaths replaces the object path with the one returned by fn.
+func (p *ComponentAttr) RemapPaths(fn func(string) string) {
+	if p.Path != "" {
+		p.Path = fn(p.Path)
+	}
+}
@@ -0,0 +1,196 @@
+package production
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+	"github.com/qmuntal/go3mf/uuid"
+)
+
+// ErrPartExists is returned by SplitObjects when the target path is already used.
+var ErrPartExists = errors.New("part name is already used")
+
+// SplitObjects moves the root model objects with the given IDs
+// into a new child model with the given path, which is the reverse of go3mf.Model.InlineChilds.
+//
+// The moved objects keep their IDs. The objects they reference through components
+// and the assets they reference, including the ones referenced by extension values
+// that implement spec.IDRemapper, are copied into the child model,
+// as they may still be used by the root model.
+// Build items and root components that reference a moved object get its path
+// in their ItemAttr or ComponentAttr.
+//
+// Missing UUIDs are set and the copied objects get new ones.
+// The production extension is added to the model extensions if not already declared.
+func SplitObjects(m *go3mf.Model, path string, ids ...uint32) error {
+	if err := checkPartName(m, path); err != nil {
+		return err
+	}
+	rootPath := func(p string) bool { return p == "" || p == m.PathOrDefault() }
+	moved := make(map[uint32]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := m.Resources.FindObject(id); !ok {
+			return specerr.ErrMissingResource
+		}
+		moved[id] = struct{}{}
+	}
+	objects := make(map[uint32]struct{})
+	assets := make(map[uint32]struct{})
+	var pending []uint32
+	for id := range moved {
+		objects[id] = struct{}{}
+		pending = append(pending, id)
+	}
+	markAsset := func(id uint32) uint32 {
+		if _, ok := m.Resources.FindAsset(id); ok {
+			assets[id] = struct{}{}
+		}
+		return id
+	}
+	for len(pending) > 0 {
+		o, _ := m.Resources.FindObject(pending[len(pending)-1])
+		pending = pending[:len(pending)-1]
+		if o == nil {
+			continue
+		}
+		markAsset(o.PID)
+		for _, a := range o.AnyAttr {
+			refIDs(a, markAsset)
+		}
+		for _, a := range o.Any {
+			refIDs(a, markAsset)
+		}
+		if o.Mesh != nil {
+			for _, t := range o.Mesh.Triangles {
+				markAsset(t.PID())
+			}
+			for _, a := range o.Mesh.AnyAttr {
+				refIDs(a, markAsset)
+			}
+			for _, a := range o.Mesh.Any {
+				refIDs(a, markAsset)
+			}
+		}
+		for _, c := range o.Components {
+			if !rootPath(c.ObjectPath("")) {
+				return ErrProdRefInNonRoot
+			}
+			if _, ok := objects[c.ObjectID]; !ok {
+				objects[c.ObjectID] = struct{}{}
+				pending = append(pending, c.ObjectID)
+			}
+		}
+	}
+	// Assets can reference other assets.
+	for n := 0; n != len(assets); {
+		n = len(assets)
+		for _, a := range m.Resources.Assets {
+			if _, ok := assets[a.Identify()]; ok {
+				refIDs(a, markAsset)
+			}
+		}
+	}
+
+	child := new(go3mf.ChildModel)
+	for _, a := range m.Resources.Assets {
+		if _, ok := assets[a.Identify()]; ok {
+			if c, ok := a.(spec.Cloner); ok {
+				a = c.Clone().(go3mf.Asset)
+			}
+			child.Resources.Assets = append(child.Resources.Assets, a)
+		}
+	}
+	objs := m.Resources.Objects[:0]
+	for _, o := range m.Resources.Objects {
+		if _, ok := objects[o.ID]; !ok {
+			objs = append(objs, o)
+			continue
+		}
+		if _, ok := moved[o.ID]; ok {
+			child.Resources.Objects = append(child.Resources.Objects, o)
+			continue
+		}
+		objs = append(objs, o)
+		o = o.Clone()
+		if u := GetObjectAttr(o); u != nil {
+			u.UUID = uuid.New()
+		}
+		for _, c := range o.Components {
+			if u := GetComponentAttr(c); u != nil {
+				u.UUID = uuid.New()
+			}
+		}
+		child.Resources.Objects = append(child.Resources.Objects, o)
+	}
+	for i := len(objs); i < len(m.Resources.Objects); i++ {
+		m.Resources.Objects[i] = nil
+	}
+	m.Resources.Objects = objs
+	for _, o := range child.Resources.Objects {
+		for _, c := range o.Components {
+			if u := GetComponentAttr(c); u != nil {
+				u.Path = ""
+			}
+		}
+	}
+
+	for _, item := range m.Build.Items {
+		if _, ok := moved[item.ObjectID]; ok && rootPath(item.ObjectPath()) {
+			if u := GetItemAttr(item); u != nil {
+				u.Path = path
+			} else {
+				item.AnyAttr = append(item.AnyAttr, &ItemAttr{Path: path})
+			}
+		}
+	}
+	for _, o := range m.Resources.Objects {
+		for _, c := range o.Components {
+			if _, ok := moved[c.ObjectID]; ok && rootPath(c.ObjectPath("")) {
+				if u := GetComponentAttr(c); u != nil {
+					u.Path = path
+				} else {
+					c.AnyAttr = append(c.AnyAttr, &ComponentAttr{Path: path})
+				}
+			}
+		}
+	}
+	if m.Childs == nil {
+		m.Childs = make(map[string]*go3mf.ChildModel)
+	}
+	m.Childs[path] = child
+	SetMissingUUIDs(m)
+	for _, e := range m.Extensions {
+		if e.Namespace == Namespace {
+			return nil
+		}
+	}
+	m.Extensions = append(m.Extensions, DefaultExtension)
+	return nil
+}
+
+func checkPartName(m *go3mf.Model, path string) error {
+	if strings.EqualFold(path, m.PathOrDefault()) {
+		return ErrPartExists
+	}
+	for p := range m.Childs {
+		if strings.EqualFold(path, p) {
+			return ErrPartExists
+		}
+	}
+	for _, a := range m.Attachments {
+		if strings.EqualFold(path, a.Path) {
+			return ErrPartExists
+		}
+	}
+	return nil
+}
+
+// refIDs calls fn with the resource IDs referenced by v.
+func refIDs(v interface{}, fn func(uint32) uint32) {
+	if r, ok := v.(spec.IDRemapper); ok {
+		r.RemapIDs(fn)
+	}
+}
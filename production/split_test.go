@@ -0,0 +1,79 @@
+package production
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+)
+
+func TestSplitObjects(t *testing.T) {
+	base := func(id uint32) *go3mf.BaseMaterials {
+		return &go3mf.BaseMaterials{ID: id, Materials: []go3mf.Base{{Name: "a", Color: color.RGBA{A: 255}}}}
+	}
+	tetrahedron := &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTriangle(0, 2, 1), go3mf.NewTriangle(0, 1, 3),
+			go3mf.NewTriangle(0, 3, 2), go3mf.NewTriangle(1, 2, 3),
+		},
+	}
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{base(1), base(9)},
+			Objects: []*go3mf.Object{
+				{ID: 2, PID: 1, AnyAttr: go3mf.AnyAttr{&ObjectAttr{UUID: "cb828680-8895-4e08-a1fc-be63e033df15"}}, Mesh: tetrahedron},
+				{ID: 3, Components: []*go3mf.Component{{ObjectID: 2}}},
+				{ID: 4, Components: []*go3mf.Component{{ObjectID: 3}}},
+			},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 3}, {ObjectID: 4}}},
+	}
+	if err := SplitObjects(m, "/3D/3dmodel.model", 3); !errors.Is(err, ErrPartExists) {
+		t.Errorf("SplitObjects() error = %v, want %v", err, ErrPartExists)
+	}
+	if err := SplitObjects(m, "/3D/other.model", 5); !errors.Is(err, specerr.ErrMissingResource) {
+		t.Errorf("SplitObjects() error = %v, want %v", err, specerr.ErrMissingResource)
+	}
+	if err := SplitObjects(m, "/3D/other.model", 3); err != nil {
+		t.Fatalf("SplitObjects() error = %v", err)
+	}
+	child := m.Childs["/3D/other.model"]
+	if child == nil {
+		t.Fatal("SplitObjects() child not created")
+	}
+	if diff := deep.Equal(child.Resources.Assets, []go3mf.Asset{base(1)}); diff != nil {
+		t.Errorf("SplitObjects() child assets = %v", diff)
+	}
+	if len(child.Resources.Objects) != 2 || child.Resources.Objects[0].ID != 2 || child.Resources.Objects[1].ID != 3 {
+		t.Fatalf("SplitObjects() child objects = %v", child.Resources.Objects)
+	}
+	if len(m.Resources.Objects) != 2 || m.Resources.Objects[0].ID != 2 || m.Resources.Objects[1].ID != 4 {
+		t.Fatalf("SplitObjects() root objects = %v", m.Resources.Objects)
+	}
+	if u := GetObjectAttr(child.Resources.Objects[0]).UUID; u == "" || u == GetObjectAttr(m.Resources.Objects[0]).UUID {
+		t.Errorf("SplitObjects() copied object UUID = %s", u)
+	}
+	if p := GetComponentAttr(m.Resources.Objects[1].Components[0]); p == nil || p.Path != "/3D/other.model" || p.UUID == "" {
+		t.Errorf("SplitObjects() component attr = %v", p)
+	}
+	if p := GetItemAttr(m.Build.Items[0]); p == nil || p.Path != "/3D/other.model" || p.UUID == "" {
+		t.Errorf("SplitObjects() item attr = %v", p)
+	}
+	if p := GetItemAttr(m.Build.Items[1]); p == nil || p.Path != "" {
+		t.Errorf("SplitObjects() item attr = %v", p)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("SplitObjects() validation error = %v", err)
+	}
+	m.InlineChilds()
+	if len(m.Childs) != 0 || len(m.Resources.Objects) != 4 {
+		t.Errorf("Model.InlineChilds() = %v", m.Resources.Objects)
+	}
+	if p := GetItemAttr(m.Build.Items[0]); p == nil || p.Path != "" || p.UUID == "" {
+		t.Errorf("Model.InlineChilds() item attr = %v", p)
+	}
+}
@@ -58,7 +58,7 @@ func (r *ReadCloser) Close() error {
 	return r.f.Close()
 }
 
-func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string, isRoot, strict bool) error {
+func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string, isRoot, strict bool, onObject func(*Object) error) error {
 	x := xml3mf.NewDecoder(r)
 	state, names := make([]spec.ElementDecoder, 0, 10), make([]xml.Name, 0, 10)
 
@@ -66,8 +66,17 @@ func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string
 		currentDecoder, tmpDecoder spec.ElementDecoder
 		currentName                xml.Name
 		errs                       specerr.List
+		objectErr                  error
 	)
-	currentDecoder = &topLevelDecoder{isRoot: isRoot, model: model, path: path}
+	top := &topLevelDecoder{isRoot: isRoot, model: model, path: path}
+	if onObject != nil {
+		top.onObject = func(obj *Object) {
+			if objectErr == nil {
+				objectErr = onObject(obj)
+			}
+		}
+	}
+	currentDecoder = top
 	var err error
 	x.OnStart = func(tp xml3mf.StartElement) {
 		if childDecoder, ok := currentDecoder.(spec.ChildElementDecoder); ok {
@@ -104,6 +113,9 @@ func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string
 	var i int
 	for {
 		err = x.RawToken()
+		if err == nil {
+			err = objectErr
+		}
 		if err != nil || (strict && errs.Len() != 0) {
 			break
 		}
@@ -133,11 +145,21 @@ func decodeModelFile(ctx context.Context, r io.Reader, model *Model, path string
 }
 
 // Decoder implements a 3mf file decoder.
+//
+// If Decrypter is not nil it is used to read the package key store
+// and to decrypt the parts protected by the Secure Content extension.
+//
+// Attachments are read from the package on demand unless EagerAttachments is true,
+// in which case they are copied into memory while decoding so they
+// remain available once the package is closed.
 type Decoder struct {
-	Strict        bool
-	p             packageReader
-	flate         func(r io.Reader) io.ReadCloser
-	nonRootModels []packageFile
+	Strict           bool
+	EagerAttachments bool
+	Decrypter        Decrypter
+	p                packageReader
+	flate            func(r io.Reader) io.ReadCloser
+	nonRootModels    []packageFile
+	onObject         func(string, *Object) error
 }
 
 // NewDecoder returns a new Decoder reading a 3mf file from r.
@@ -165,6 +187,41 @@ func (d *Decoder) DecodeContext(ctx context.Context, model *Model) error {
 	return d.processRootModel(ctx, rootFile, model)
 }
 
+// DecodeObjects reads the 3mf file and unmarshall its content into the model,
+// except for the objects, which are handed over to fn as soon as they are decoded
+// instead of being added to the model resources. This allows processing files
+// whose objects do not fit in memory at once.
+//
+// The child models are decoded first, one at a time, and then the root model is decoded.
+// path is the child model path, or empty for the root model, and fn is never called concurrently.
+// Once DecodeObjects returns the model contains all the remaining data, such as the build and the metadata.
+// If fn returns an error the decoding stops and the error is returned.
+func (d *Decoder) DecodeObjects(ctx context.Context, model *Model, fn func(path string, obj *Object) error) error {
+	d.onObject = fn
+	defer func() { d.onObject = nil }()
+	rootFile, err := d.processOPC(model)
+	if err != nil {
+		return err
+	}
+	for i := range d.nonRootModels {
+		if err := d.readChildModel(ctx, i, model); err != nil {
+			return err
+		}
+	}
+	return d.processRootModel(ctx, rootFile, model)
+}
+
+// objectHandler returns the function called by decodeModelFile
+// for each decoded object, or nil if the objects have to be added to the model.
+func (d *Decoder) objectHandler(path string) func(*Object) error {
+	if d.onObject == nil {
+		return nil
+	}
+	return func(obj *Object) error {
+		return d.onObject(path, obj)
+	}
+}
+
 // UnmarshalModel fills a model with the data of a root model file
 // using not strict mode.
 func UnmarshalModel(data []byte, model *Model) error {
@@ -179,7 +236,7 @@ func (d *Decoder) processRootModel(ctx context.Context, rootFile packageFile, mo
 		return err
 	}
 	defer f.Close()
-	err = decodeModelFile(ctx, f, model, rootFile.Name(), true, d.Strict)
+	err = decodeModelFile(ctx, f, model, rootFile.Name(), true, d.Strict, d.objectHandler(""))
 	if err != nil {
 		return err
 	}
@@ -215,11 +272,15 @@ func (d *Decoder) processOPC(model *Model) (packageFile, error) {
 	if err := d.p.Open(d.flate); err != nil {
 		return nil, err
 	}
+	p, err := d.decryptPackage()
+	if err != nil {
+		return nil, err
+	}
 	var rootFile packageFile
-	for _, r := range d.p.Relationships() {
+	for _, r := range p.Relationships() {
 		if r.Type == RelType3DModel {
 			var ok bool
-			rootFile, ok = d.p.FindFileFromName(r.Path)
+			rootFile, ok = p.FindFileFromName(r.Path)
 			if !ok {
 				return nil, errors.New("package root model points to an unexisting file")
 			}
@@ -228,7 +289,9 @@ func (d *Decoder) processOPC(model *Model) (packageFile, error) {
 			for _, file := range d.nonRootModels {
 				d.extractCoreAttachments(file, model, false)
 			}
-		} else if att, ok := d.p.FindFileFromName(r.Path); ok {
+		} else if r.Type == RelTypeKeyStore && d.Decrypter != nil {
+			continue
+		} else if att, ok := p.FindFileFromName(r.Path); ok {
 			model.RootRelationships = append(model.RootRelationships, r)
 			model.Attachments = d.addAttachment(model.Attachments, att)
 		}
@@ -239,11 +302,37 @@ func (d *Decoder) processOPC(model *Model) (packageFile, error) {
 	return rootFile, nil
 }
 
+func (d *Decoder) decryptPackage() (packageReader, error) {
+	if d.Decrypter == nil {
+		return d.p, nil
+	}
+	for _, r := range d.p.Relationships() {
+		if r.Type != RelTypeKeyStore {
+			continue
+		}
+		file, ok := d.p.FindFileFromName(r.Path)
+		if !ok {
+			return nil, errors.New("package key store points to an unexisting file")
+		}
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = d.Decrypter.ReadKeyStore(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+	return &decryptReader{packageReader: d.p, d: d.Decrypter}, nil
+}
+
 func (d *Decoder) extractCoreAttachments(modelFile packageFile, model *Model, isRoot bool) {
 	for _, rel := range modelFile.Relationships() {
 		if file, ok := modelFile.FindFileFromName(rel.Path); ok {
 			if isRoot {
-				if rel.Type == RelType3DModel {
+				if isModelRelationship(rel, file) {
 					d.nonRootModels = append(d.nonRootModels, file)
 					if model.Childs == nil {
 						model.Childs = make(map[string]*ChildModel)
@@ -253,7 +342,7 @@ func (d *Decoder) extractCoreAttachments(modelFile packageFile, model *Model, is
 					model.Attachments = d.addAttachment(model.Attachments, file)
 					model.Relationships = append(model.Relationships, rel)
 				}
-			} else if rel.Type != RelType3DModel {
+			} else if !isModelRelationship(rel, file) {
 				if child, ok := model.Childs[modelFile.Name()]; ok {
 					model.Attachments = d.addAttachment(model.Attachments, file)
 					child.Relationships = append(child.Relationships, rel)
@@ -263,12 +352,26 @@ func (d *Decoder) extractCoreAttachments(modelFile packageFile, model *Model, is
 	}
 }
 
+// isModelRelationship reports whether rel targets a model part,
+// taking into account that encrypted models use their own relationship type.
+func isModelRelationship(rel Relationship, file packageFile) bool {
+	return rel.Type == RelType3DModel ||
+		(rel.Type == RelTypeEncryptedFile && file.ContentType() == ContentType3DModel)
+}
+
 func (d *Decoder) addAttachment(attachments []Attachment, file packageFile) []Attachment {
 	for _, att := range attachments {
 		if strings.EqualFold(att.Path, file.Name()) {
 			return attachments
 		}
 	}
+	if !d.EagerAttachments {
+		return append(attachments, Attachment{
+			Path:        file.Name(),
+			Stream:      &lazyReader{file: file},
+			ContentType: file.ContentType(),
+		})
+	}
 	if buff, err := copyFile(file); err == nil {
 		return append(attachments, Attachment{
 			Path:        file.Name(),
@@ -286,7 +389,7 @@ func (d *Decoder) readChildModel(ctx context.Context, i int, model *Model) error
 		return err
 	}
 	defer file.Close()
-	err = decodeModelFile(ctx, file, model, attachment.Name(), false, d.Strict)
+	err = decodeModelFile(ctx, file, model, attachment.Name(), false, d.Strict, d.objectHandler(attachment.Name()))
 	select {
 	case <-ctx.Done():
 		err = ctx.Err()
@@ -306,6 +409,31 @@ func copyFile(file packageFile) (io.Reader, error) {
 	return buff, err
 }
 
+// lazyReader reads a package file on demand.
+// The file is opened on the first call to Read and closed once consumed.
+type lazyReader struct {
+	file packageFile
+	rc   io.ReadCloser
+	err  error
+}
+
+func (r *lazyReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.rc == nil {
+		if r.rc, r.err = r.file.Open(); r.err != nil {
+			return 0, r.err
+		}
+	}
+	n, err := r.rc.Read(p)
+	if err != nil {
+		r.rc.Close()
+		r.err = err
+	}
+	return n, err
+}
+
 type fakePackageFile struct {
 	data []byte
 }
@@ -200,7 +200,7 @@ type mockPackage struct {
 func newMockPackage(other *mockFile) *mockPackage {
 	m := new(mockPackage)
 	m.On("Open", mock.Anything).Return(nil).Maybe()
-	m.On("Create", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	m.On("Relationships").Return([]Relationship{{Path: DefaultModelPath, Type: RelType3DModel}}).Maybe()
 	m.On("FindFileFromName", mock.Anything).Return(other, other != nil).Maybe()
 	return m
@@ -220,8 +220,8 @@ func (m *mockPackage) AddRelationship(args0 Relationship) {
 	m.Called(args0)
 }
 
-func (m *mockPackage) Create(args0, args1 string) (packagePart, error) {
-	args := m.Called(args0, args1)
+func (m *mockPackage) Create(args0, args1 string, args2 Compression) (packagePart, error) {
+	args := m.Called(args0, args1, args2)
 	return args.Get(0).(packagePart), args.Error(1)
 }
 
@@ -247,21 +247,24 @@ func TestDecoder_processOPC(t *testing.T) {
 		{"noRoot", &Decoder{p: newMockPackage(nil)}, &Model{}, true},
 		{"noRels", &Decoder{p: newMockPackage(newMockFile("/a.model", nil, nil, false))}, &Model{Path: "/a.model"}, false},
 		{"withThumb", &Decoder{
-			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelTypeThumbnail, Path: "/a.png"}}, newMockFile("/a.png", nil, nil, false), false)),
+			EagerAttachments: true,
+			p:                newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelTypeThumbnail, Path: "/a.png"}}, newMockFile("/a.png", nil, nil, false), false)),
 		}, &Model{
 			Path:          "/a.model",
 			Relationships: []Relationship{{Path: "/a.png", Type: RelTypeThumbnail}},
 			Attachments:   []Attachment{{Path: "/a.png", Stream: new(bytes.Buffer)}},
 		}, false},
 		{"withPrintTicket", &Decoder{
-			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelTypePrintTicket, Path: "/pc.png"}}, newMockFile("/pc.png", nil, nil, false), false)),
+			EagerAttachments: true,
+			p:                newMockPackage(newMockFile("/a.model", []Relationship{{Type: RelTypePrintTicket, Path: "/pc.png"}}, newMockFile("/pc.png", nil, nil, false), false)),
 		}, &Model{
 			Path:          "/a.model",
 			Relationships: []Relationship{{Path: "/pc.png", Type: RelTypePrintTicket}},
 			Attachments:   []Attachment{{Path: "/pc.png", Stream: new(bytes.Buffer)}},
 		}, false},
 		{"withExtRel", &Decoder{
-			p: newMockPackage(newMockFile("/a.model", []Relationship{{Type: extType, Path: "/other.png"}}, newMockFile("/other.png", nil, nil, false), false)),
+			EagerAttachments: true,
+			p:                newMockPackage(newMockFile("/a.model", []Relationship{{Type: extType, Path: "/other.png"}}, newMockFile("/other.png", nil, nil, false), false)),
 		}, &Model{
 			Path:          "/a.model",
 			Relationships: []Relationship{{Path: "/other.png", Type: extType}},
@@ -311,7 +314,7 @@ func TestDecoder_processRootModel_Fail(t *testing.T) {
 
 func TestDecoder_processRootModel(t *testing.T) {
 	Register(fakeSpec.Namespace, new(qmExtension))
-	baseMaterials := &BaseMaterials{ID: 5, Materials: []Base{
+	baseMaterials := &BaseMaterials{ID: 5, DisplayPropertiesID: 10, Materials: []Base{
 		{Name: "Blue PLA", Color: color.RGBA{0, 0, 255, 255}},
 		{Name: "Red ABS", Color: color.RGBA{255, 0, 0, 255}},
 	}}
@@ -343,6 +346,10 @@ func TestDecoder_processRootModel(t *testing.T) {
 		NewTrianglePID(3, 0, 4, 5, 0, 0, 0),
 		NewTrianglePID(4, 7, 3, 5, 0, 0, 0),
 	}...)
+	meshRes.Mesh.TriangleSets = []TriangleSet{
+		{Name: "Top", Identifier: "top", Refs: []uint32{2, 3}},
+		{Name: "Sides", Identifier: "sides", Refs: []uint32{4, 5, 6, 7, 8, 9, 11}},
+	}
 
 	components := &Object{
 		ID: 20, Type: ObjectTypeSupport,
@@ -369,7 +376,7 @@ func TestDecoder_processRootModel(t *testing.T) {
 	got.Path = "/3D/3dmodel.model"
 	rootFile := new(modelBuilder).withDefaultModel().withElement(`
 		<resources>
-			<basematerials id="5">
+			<basematerials id="5" displaypropertiesid="10">
 				<base name="Blue PLA" displaycolor="#0000FF" />
 				<base name="Red ABS" displaycolor="#FF0000" />
 			</basematerials>
@@ -399,6 +406,16 @@ func TestDecoder_processRootModel(t *testing.T) {
 						<triangle v1="3" v2="0" v3="4" />
 						<triangle v1="4" v2="7" v3="3" />
 					</triangles>
+					<t:trianglesets xmlns:t="http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07">
+						<t:triangleset name="Top" identifier="top">
+							<t:ref index="2" />
+							<t:ref index="3" />
+						</t:triangleset>
+						<t:triangleset name="Sides" identifier="sides">
+							<t:refrange startindex="4" endindex="9" />
+							<t:ref index="11" />
+						</t:triangleset>
+					</t:trianglesets>
 				</mesh>
 			</object>
 			<object id="20" type="support">
@@ -503,6 +520,117 @@ func TestDecoder_Decode(t *testing.T) {
 	}
 }
 
+func TestDecoder_DecodeObjects(t *testing.T) {
+	mesh := func() *Mesh {
+		return &Mesh{Vertices: []Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}}, Triangles: []Triangle{NewTriangle(0, 1, 2)}}
+	}
+	m := &Model{
+		Metadata: []Metadata{{Name: xml.Name{Local: "Application"}, Value: "go3mf app"}},
+		Resources: Resources{
+			Assets:  []Asset{&BaseMaterials{ID: 1, Materials: []Base{{Name: "a", Color: color.RGBA{A: 255}}}}},
+			Objects: []*Object{{ID: 2, Mesh: mesh()}, {ID: 3, Components: []*Component{{ObjectID: 2}}}},
+		},
+		Build: Build{Items: []*Item{{ObjectID: 3}}},
+		Childs: map[string]*ChildModel{
+			"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1, Mesh: mesh()}}}},
+		},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	t.Run("base", func(t *testing.T) {
+		var got []string
+		model := new(Model)
+		err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).DecodeObjects(context.Background(), model, func(path string, obj *Object) error {
+			got = append(got, fmt.Sprintf("%s#%d", path, obj.ID))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Decoder.DecodeObjects() error = %v", err)
+		}
+		if diff := deep.Equal(got, []string{"/3D/other.model#1", "#2", "#3"}); diff != nil {
+			t.Errorf("Decoder.DecodeObjects() = %v", diff)
+		}
+		if len(model.Resources.Objects) != 0 || len(model.Childs["/3D/other.model"].Resources.Objects) != 0 {
+			t.Error("Decoder.DecodeObjects() objects should not be added to the model")
+		}
+		if diff := deep.Equal(model.Resources.Assets, m.Resources.Assets); diff != nil {
+			t.Errorf("Decoder.DecodeObjects() assets = %v", diff)
+		}
+		if diff := deep.Equal(model.Build, m.Build); diff != nil {
+			t.Errorf("Decoder.DecodeObjects() build = %v", diff)
+		}
+		if diff := deep.Equal(model.Metadata, m.Metadata); diff != nil {
+			t.Errorf("Decoder.DecodeObjects() metadata = %v", diff)
+		}
+	})
+	t.Run("stop", func(t *testing.T) {
+		want := errors.New("stop")
+		var calls int
+		err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).DecodeObjects(context.Background(), new(Model), func(string, *Object) error {
+			calls++
+			return want
+		})
+		if err != want {
+			t.Errorf("Decoder.DecodeObjects() error = %v, want %v", err, want)
+		}
+		if calls != 1 {
+			t.Errorf("Decoder.DecodeObjects() calls = %d, want 1", calls)
+		}
+	})
+}
+
+func TestDecoder_Decode_LazyAttachments(t *testing.T) {
+	m := &Model{
+		Attachments:   []Attachment{{ContentType: "image/png", Path: "/3D/Textures/a.png", Stream: bytes.NewBufferString("texture")}},
+		Relationships: []Relationship{{Path: "/3D/Textures/a.png", Type: "texture"}},
+	}
+	buff := new(bytes.Buffer)
+	if err := NewEncoder(buff).Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	got := new(Model)
+	if err := NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len())).Decode(got); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	att := &got.Attachments[0]
+	if _, ok := att.Stream.(*lazyReader); !ok {
+		t.Fatalf("Decoder.Decode() Stream = %T, want lazy", att.Stream)
+	}
+	for i := 0; i < 2; i++ {
+		r, err := att.Open()
+		if err != nil {
+			t.Fatalf("Attachment.Open() error = %v", err)
+		}
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		if string(b) != "texture" {
+			t.Errorf("Attachment.Open() = %s, want texture", b)
+		}
+	}
+	b, _ := ioutil.ReadAll(att.Stream)
+	if string(b) != "texture" {
+		t.Errorf("Attachment.Stream = %s, want texture", b)
+	}
+	if b, _ = ioutil.ReadAll(att.Stream); len(b) != 0 {
+		t.Errorf("Attachment.Stream should be consumed, got %s", b)
+	}
+	buff2 := new(bytes.Buffer)
+	if err := NewEncoder(buff2).Encode(got); err != nil {
+		t.Fatalf("Encoder.Encode() lazy error = %v", err)
+	}
+	got2 := new(Model)
+	d := NewDecoder(bytes.NewReader(buff2.Bytes()), int64(buff2.Len()))
+	d.EagerAttachments = true
+	if err := d.Decode(got2); err != nil {
+		t.Fatalf("Decoder.Decode() error = %v", err)
+	}
+	if s := got2.Attachments[0].Stream.(*bytes.Buffer).String(); s != "texture" {
+		t.Errorf("Encoder.Encode() lazy = %s, want texture", s)
+	}
+}
+
 func Test_modelFile_Decode(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -532,7 +660,7 @@ func Test_modelFile_Decode(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := decodeModelFile(tt.args.ctx, tt.args.r, new(Model), "", true, false); (err != nil) != tt.wantErr {
+			if err := decodeModelFile(tt.args.ctx, tt.args.r, new(Model), "", true, false, nil); (err != nil) != tt.wantErr {
 				t.Errorf("modelFile.Decode() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -570,6 +698,8 @@ func TestDecoder_processRootModel_warns(t *testing.T) {
 		fmt.Sprintf("Resources@BaseMaterials#1: %v", specerr.NewParseAttrError("id", true)),
 		fmt.Sprintf("Resources@Object#0@Mesh@Point3D#8: %v", specerr.NewParseAttrError("x", true)),
 		fmt.Sprintf("Resources@Object#0@Mesh@Triangle#13: %v", specerr.NewParseAttrError("v1", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#0@triangleRef#0: %v", specerr.NewParseAttrError("index", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#0@triangleRefRange#1: %v", specerr.NewParseAttrError("endindex", true)),
 		fmt.Sprintf("Resources@Object#1: %v", specerr.NewParseAttrError("pid", false)),
 		fmt.Sprintf("Resources@Object#1: %v", specerr.NewParseAttrError("pindex", false)),
 		fmt.Sprintf("Resources@Object#1: %v", specerr.NewParseAttrError("type", false)),
@@ -623,6 +753,12 @@ func TestDecoder_processRootModel_warns(t *testing.T) {
 						<triangle v1="3" v2="0" v3="4" />
 						<triangle v1="a" v2="7" v3="3" />
 					</triangles>
+					<t:trianglesets xmlns:t="http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07">
+						<t:triangleset name="Top" identifier="top">
+							<t:ref index="a" />
+							<t:refrange startindex="3" endindex="1" />
+						</t:triangleset>
+					</t:trianglesets>
 				</mesh>
 			</object>
 			<object id="22" pid="a" pindex="a" type="invalid" />
@@ -662,6 +798,48 @@ func TestDecoder_processRootModel_warns(t *testing.T) {
 	}
 }
 
+func TestDecoder_processRootModel_hugeTriangleRange(t *testing.T) {
+	want := fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#0@triangleRefRange#1: %v", specerr.ErrIndexOutOfBounds)
+	got := new(Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := new(modelBuilder).withElement(`
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02">
+		<resources>
+			<object id="1">
+				<mesh>
+					<vertices>
+						<vertex x="0" y="0" z="0" />
+						<vertex x="1" y="0" z="0" />
+						<vertex x="0" y="1" z="0" />
+					</vertices>
+					<triangles>
+						<triangle v1="0" v2="1" v3="2" />
+					</triangles>
+					<t:trianglesets xmlns:t="http://schemas.microsoft.com/3dmanufacturing/trianglesets/2021/07">
+						<t:triangleset name="All" identifier="all">
+							<t:refrange startindex="0" endindex="0" />
+							<t:refrange startindex="0" endindex="4294967295" />
+						</t:triangleset>
+					</t:trianglesets>
+				</mesh>
+			</object>
+		</resources>
+		<build />
+		</model>
+		`).build("")
+
+	d := new(Decoder)
+	d.Strict = false
+	err := d.processRootModel(context.Background(), rootFile, got)
+	if err == nil || err.Error() != want {
+		t.Fatalf("Decoder.processRootModel() error = %v, want %s", err, want)
+	}
+	sets := got.Resources.Objects[0].Mesh.TriangleSets
+	if len(sets) != 1 || len(sets[0].Refs) != 1 {
+		t.Errorf("Decoder.processRootModel() triangle sets = %v", sets)
+	}
+}
+
 func TestOpenReader(t *testing.T) {
 	r, err := OpenReader("testdata/cube.3mf")
 	if err != nil {
@@ -0,0 +1,76 @@
+package repair
+
+import (
+	"sort"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// FillHoles closes the simple holes of the mesh, which are boundary loops that
+// do not visit a vertex twice, with a fan of triangles oriented like their neighbors.
+// Holes with more than maxEdges edges are kept, unless maxEdges is zero.
+// The new triangles get the property group of a neighbor triangle and the property
+// its first vertex uses. It returns the number of added triangles.
+func FillHoles(m *go3mf.Mesh, maxEdges int) int {
+	// next maps each boundary vertex to the following vertex in the hole loop,
+	// which traverses the boundary edges in the opposite direction.
+	next := make(map[uint32]uint32)
+	owner := make(map[uint32]uint32)
+	branched := make(map[uint32]bool)
+	for k, tris := range edgeTriangles(m) {
+		if len(tris) != 1 {
+			continue
+		}
+		a, b := k.a, k.b
+		if hasDirectedEdge(m.Triangles[tris[0]], k.a, k.b) {
+			a, b = b, a
+		}
+		// The triangle traverses b->a, so the hole goes from a to b.
+		if _, ok := next[a]; ok {
+			branched[a] = true
+		}
+		next[a] = b
+		owner[a] = tris[0]
+	}
+	var added int
+	visited := make(map[uint32]bool)
+	for _, start := range sortedKeys(next) {
+		if visited[start] {
+			continue
+		}
+		loop := []uint32{start}
+		simple := !branched[start]
+		visited[start] = true
+		for v := next[start]; v != start; v = next[v] {
+			if visited[v] || branched[v] {
+				simple = false
+				break
+			}
+			if _, ok := next[v]; !ok {
+				simple = false
+				break
+			}
+			visited[v] = true
+			loop = append(loop, v)
+		}
+		if !simple || len(loop) < 3 || (maxEdges > 0 && len(loop) > maxEdges) {
+			continue
+		}
+		ref := m.Triangles[owner[start]]
+		p1, _, _ := ref.PIndices()
+		for i := 1; i+1 < len(loop); i++ {
+			m.Triangles = append(m.Triangles, go3mf.NewTrianglePID(loop[0], loop[i], loop[i+1], ref.PID(), p1, p1, p1))
+			added++
+		}
+	}
+	return added
+}
+
+func sortedKeys(m map[uint32]uint32) []uint32 {
+	keys := make([]uint32, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
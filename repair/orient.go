@@ -0,0 +1,109 @@
+package repair
+
+import "github.com/qmuntal/go3mf"
+
+type edgeKey struct {
+	a, b uint32
+}
+
+func newEdgeKey(a, b uint32) edgeKey {
+	if a < b {
+		return edgeKey{a, b}
+	}
+	return edgeKey{b, a}
+}
+
+// hasDirectedEdge reports whether t traverses the edge from a to b.
+func hasDirectedEdge(t go3mf.Triangle, a, b uint32) bool {
+	v1, v2, v3 := t.Indices()
+	return (v1 == a && v2 == b) || (v2 == a && v3 == b) || (v3 == a && v1 == b)
+}
+
+// edgeTriangles returns the triangles that use each edge.
+func edgeTriangles(m *go3mf.Mesh) map[edgeKey][]uint32 {
+	edges := make(map[edgeKey][]uint32)
+	for i, t := range m.Triangles {
+		v1, v2, v3 := t.Indices()
+		for _, e := range [3][2]uint32{{v1, v2}, {v2, v3}, {v3, v1}} {
+			if e[0] != e[1] {
+				k := newEdgeKey(e[0], e[1])
+				edges[k] = append(edges[k], uint32(i))
+			}
+		}
+	}
+	return edges
+}
+
+// Orient makes the orientation of the triangles consistent within each
+// connected group of triangles, propagating it through the manifold edges,
+// and then flips the groups whose signed volume is negative so their normals point outwards.
+// It returns the number of flipped triangles.
+func Orient(m *go3mf.Mesh) int {
+	edges := edgeTriangles(m)
+	visited := make([]bool, len(m.Triangles))
+	flipped := make([]bool, len(m.Triangles))
+	var count int
+	for seed := range m.Triangles {
+		if visited[seed] {
+			continue
+		}
+		visited[seed] = true
+		group := []uint32{uint32(seed)}
+		for i := 0; i < len(group); i++ {
+			cur := group[i]
+			t := m.Triangles[cur]
+			v1, v2, v3 := t.Indices()
+			for _, e := range [3][2]uint32{{v1, v2}, {v2, v3}, {v3, v1}} {
+				tris := edges[newEdgeKey(e[0], e[1])]
+				if len(tris) != 2 {
+					continue
+				}
+				nb := tris[0]
+				if nb == cur {
+					nb = tris[1]
+				}
+				if visited[nb] {
+					continue
+				}
+				visited[nb] = true
+				// The neighbor has to traverse the shared edge in the opposite direction.
+				sameDir := hasDirectedEdge(m.Triangles[nb], e[0], e[1])
+				flipped[nb] = sameDir != flipped[cur]
+				group = append(group, nb)
+			}
+		}
+		var volume float64
+		for _, i := range group {
+			t := m.Triangles[i]
+			if flipped[i] {
+				t = flip(t)
+			}
+			volume += signedVolume(m, t)
+		}
+		for _, i := range group {
+			if volume < 0 {
+				flipped[i] = !flipped[i]
+			}
+			if flipped[i] {
+				m.Triangles[i] = flip(m.Triangles[i])
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// signedVolume returns six times the signed volume of the tetrahedron
+// formed by the triangle and the origin.
+func signedVolume(m *go3mf.Mesh, t go3mf.Triangle) float64 {
+	v1, v2, v3 := t.Indices()
+	n := uint32(len(m.Vertices))
+	if v1 >= n || v2 >= n || v3 >= n {
+		return 0
+	}
+	a, b, c := m.Vertices[v1], m.Vertices[v2], m.Vertices[v3]
+	ax, ay, az := float64(a[0]), float64(a[1]), float64(a[2])
+	bx, by, bz := float64(b[0]), float64(b[1]), float64(b[2])
+	cx, cy, cz := float64(c[0]), float64(c[1]), float64(c[2])
+	return ax*(by*cz-bz*cy) - ay*(bx*cz-bz*cx) + az*(bx*cy-by*cx)
+}
@@ -0,0 +1,262 @@
+// Package repair implements operations that fix common defects of go3mf meshes.
+//
+// The operations work on the mesh vertices and triangles, meshes provided
+// by a go3mf.MeshSource are not supported. Triangle properties are preserved
+// and triangle sets are updated when triangles are removed.
+package repair
+
+import (
+	"math"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Step defines a repair operation.
+type Step uint8
+
+// Supported repair steps, in the order they are applied by Repair.
+const (
+	StepWeld Step = iota
+	StepRemoveDegenerate
+	StepRemoveDuplicates
+	StepOrient
+	StepFillHoles
+	StepRemoveUnreferenced
+)
+
+func (s Step) String() string {
+	return map[Step]string{
+		StepWeld:               "weld vertices",
+		StepRemoveDegenerate:   "remove degenerate triangles",
+		StepRemoveDuplicates:   "remove duplicate triangles",
+		StepOrient:             "orient triangles",
+		StepFillHoles:          "fill holes",
+		StepRemoveUnreferenced: "remove unreferenced vertices",
+	}[s]
+}
+
+// Report describes the result of a repair step.
+//
+// Count is the number of merged vertices for StepWeld, removed triangles
+// for StepRemoveDegenerate and StepRemoveDuplicates, flipped triangles for StepOrient,
+// added triangles for StepFillHoles and removed vertices for StepRemoveUnreferenced.
+type Report struct {
+	Step  Step
+	Count int
+}
+
+// Options defines the repair parameters.
+//
+// Tolerance is the maximum distance between two vertices to be welded,
+// zero means that only vertices at the same position are welded.
+// MaxHoleEdges is the maximum number of edges of the holes to be filled,
+// zero means that every simple hole is filled.
+type Options struct {
+	Tolerance    float32
+	MaxHoleEdges int
+}
+
+// Repair applies all the repair steps to m and returns a report for each of them.
+func Repair(m *go3mf.Mesh, opts Options) []Report {
+	return []Report{
+		{StepWeld, Weld(m, opts.Tolerance)},
+		{StepRemoveDegenerate, RemoveDegenerate(m)},
+		{StepRemoveDuplicates, RemoveDuplicates(m)},
+		{StepOrient, Orient(m)},
+		{StepFillHoles, FillHoles(m, opts.MaxHoleEdges)},
+		{StepRemoveUnreferenced, RemoveUnreferenced(m)},
+	}
+}
+
+// Weld merges the vertices that are within tolerance of a previous vertex
+// into it and returns the number of merged vertices.
+// Triangles can become degenerate after welding.
+func Weld(m *go3mf.Mesh, tolerance float32) int {
+	if tolerance < 0 {
+		tolerance = 0
+	}
+	g := newWeldGrid(tolerance)
+	remap := make([]uint32, len(m.Vertices))
+	vertices := m.Vertices[:0]
+	for i, v := range m.Vertices {
+		if idx, ok := g.find(v); ok {
+			remap[i] = idx
+			continue
+		}
+		idx := uint32(len(vertices))
+		g.add(v, idx)
+		remap[i] = idx
+		vertices = append(vertices, v)
+	}
+	merged := len(m.Vertices) - len(vertices)
+	m.Vertices = vertices
+	remapTriangles(m, remap)
+	return merged
+}
+
+type weldCell [3]int64
+
+// weldGrid indexes vertices in cubic cells of the tolerance size,
+// so the welding candidates are in the neighbor cells.
+type weldGrid struct {
+	tolerance float64
+	cells     map[weldCell][]weldEntry
+}
+
+type weldEntry struct {
+	v   go3mf.Point3D
+	idx uint32
+}
+
+func newWeldGrid(tolerance float32) *weldGrid {
+	return &weldGrid{tolerance: float64(tolerance), cells: make(map[weldCell][]weldEntry)}
+}
+
+func (g *weldGrid) cell(v go3mf.Point3D) weldCell {
+	if g.tolerance == 0 {
+		return weldCell{int64(math.Float32bits(v[0])), int64(math.Float32bits(v[1])), int64(math.Float32bits(v[2]))}
+	}
+	return weldCell{
+		int64(math.Floor(float64(v[0]) / g.tolerance)),
+		int64(math.Floor(float64(v[1]) / g.tolerance)),
+		int64(math.Floor(float64(v[2]) / g.tolerance)),
+	}
+}
+
+func (g *weldGrid) add(v go3mf.Point3D, idx uint32) {
+	c := g.cell(v)
+	g.cells[c] = append(g.cells[c], weldEntry{v, idx})
+}
+
+func (g *weldGrid) find(v go3mf.Point3D) (uint32, bool) {
+	c := g.cell(v)
+	if g.tolerance == 0 {
+		for _, e := range g.cells[c] {
+			if e.v == v {
+				return e.idx, true
+			}
+		}
+		return 0, false
+	}
+	tol2 := g.tolerance * g.tolerance
+	for x := c[0] - 1; x <= c[0]+1; x++ {
+		for y := c[1] - 1; y <= c[1]+1; y++ {
+			for z := c[2] - 1; z <= c[2]+1; z++ {
+				for _, e := range g.cells[weldCell{x, y, z}] {
+					dx, dy, dz := float64(e.v[0]-v[0]), float64(e.v[1]-v[1]), float64(e.v[2]-v[2])
+					if dx*dx+dy*dy+dz*dz <= tol2 {
+						return e.idx, true
+					}
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// RemoveDegenerate removes the triangles that repeat a vertex or have zero area
+// and returns the number of removed triangles.
+func RemoveDegenerate(m *go3mf.Mesh) int {
+	remove := make(map[uint32]struct{})
+	for _, issue := range m.AnalyzeTopology() {
+		if issue.Kind == go3mf.IssueDegenerateTriangle {
+			remove[issue.Triangles[0]] = struct{}{}
+		}
+	}
+	removeTriangles(m, remove)
+	return len(remove)
+}
+
+// RemoveDuplicates removes the triangles that use the same vertices
+// as a previous triangle, regardless of their order,
+// and returns the number of removed triangles.
+func RemoveDuplicates(m *go3mf.Mesh) int {
+	remove := make(map[uint32]struct{})
+	for _, issue := range m.AnalyzeTopology() {
+		if issue.Kind == go3mf.IssueDuplicateTriangle {
+			for _, t := range issue.Triangles[1:] {
+				remove[t] = struct{}{}
+			}
+		}
+	}
+	removeTriangles(m, remove)
+	return len(remove)
+}
+
+// RemoveUnreferenced removes the vertices that are not used by any triangle
+// and returns the number of removed vertices.
+func RemoveUnreferenced(m *go3mf.Mesh) int {
+	used := make([]bool, len(m.Vertices))
+	for _, t := range m.Triangles {
+		v1, v2, v3 := t.Indices()
+		for _, v := range [3]uint32{v1, v2, v3} {
+			if int(v) < len(used) {
+				used[v] = true
+			}
+		}
+	}
+	remap := make([]uint32, len(m.Vertices))
+	vertices := m.Vertices[:0]
+	for i, v := range m.Vertices {
+		if used[i] {
+			remap[i] = uint32(len(vertices))
+			vertices = append(vertices, v)
+		}
+	}
+	removed := len(m.Vertices) - len(vertices)
+	m.Vertices = vertices
+	remapTriangles(m, remap)
+	return removed
+}
+
+// remapTriangles replaces the triangle vertices with the ones in remap,
+// keeping the out of range indices.
+func remapTriangles(m *go3mf.Mesh, remap []uint32) {
+	at := func(v uint32) uint32 {
+		if int(v) < len(remap) {
+			return remap[v]
+		}
+		return v
+	}
+	for i, t := range m.Triangles {
+		v1, v2, v3 := t.Indices()
+		p1, p2, p3 := t.PIndices()
+		m.Triangles[i] = go3mf.NewTrianglePID(at(v1), at(v2), at(v3), t.PID(), p1, p2, p3)
+	}
+}
+
+// removeTriangles removes the triangles with the given indices
+// and updates the triangle set references.
+func removeTriangles(m *go3mf.Mesh, remove map[uint32]struct{}) {
+	if len(remove) == 0 {
+		return
+	}
+	remap := make([]int64, len(m.Triangles))
+	triangles := m.Triangles[:0]
+	for i, t := range m.Triangles {
+		if _, ok := remove[uint32(i)]; ok {
+			remap[i] = -1
+			continue
+		}
+		remap[i] = int64(len(triangles))
+		triangles = append(triangles, t)
+	}
+	m.Triangles = triangles
+	for i := range m.TriangleSets {
+		ts := &m.TriangleSets[i]
+		refs := ts.Refs[:0]
+		for _, r := range ts.Refs {
+			if int(r) < len(remap) && remap[r] >= 0 {
+				refs = append(refs, uint32(remap[r]))
+			}
+		}
+		ts.Refs = refs
+	}
+}
+
+// flip reverses the orientation of the triangle, keeping the properties of each vertex.
+func flip(t go3mf.Triangle) go3mf.Triangle {
+	v1, v2, v3 := t.Indices()
+	p1, p2, p3 := t.PIndices()
+	return go3mf.NewTrianglePID(v1, v3, v2, t.PID(), p1, p3, p2)
+}
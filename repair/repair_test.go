@@ -0,0 +1,185 @@
+package repair
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+// newCube returns a closed unit cube with the triangles oriented outwards.
+func newCube() *go3mf.Mesh {
+	m := new(go3mf.Mesh)
+	for i := 0; i < 8; i++ {
+		m.Vertices = append(m.Vertices, go3mf.Point3D{float32(i & 1), float32(i >> 1 & 1), float32(i >> 2 & 1)})
+	}
+	for _, t := range [][3]uint32{
+		{0, 2, 1}, {1, 2, 3}, {4, 5, 6}, {5, 7, 6}, {0, 1, 5}, {0, 5, 4},
+		{2, 6, 7}, {2, 7, 3}, {0, 4, 6}, {0, 6, 2}, {1, 3, 7}, {1, 7, 5},
+	} {
+		m.Triangles = append(m.Triangles, go3mf.NewTriangle(t[0], t[1], t[2]))
+	}
+	return m
+}
+
+func checkClosedCube(t *testing.T, m *go3mf.Mesh) {
+	t.Helper()
+	if issues := m.AnalyzeTopology(); len(issues) != 0 {
+		t.Errorf("mesh has topology issues: %v", issues)
+	}
+	if v := m.MassProperties().Volume; math.Abs(v-1) > 1e-6 {
+		t.Errorf("mesh volume = %v, want 1", v)
+	}
+}
+
+func TestWeld(t *testing.T) {
+	m := &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1.001, 0, 0}, {0, 1, 0}, {1, 1, 0}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTrianglePID(0, 1, 2, 1, 0, 1, 2),
+			go3mf.NewTrianglePID(3, 5, 4, 1, 3, 4, 5),
+		},
+	}
+	exact := &go3mf.Mesh{Vertices: append([]go3mf.Point3D(nil), m.Vertices...), Triangles: append([]go3mf.Triangle(nil), m.Triangles...)}
+	if got := Weld(exact, 0); got != 1 {
+		t.Errorf("Weld() = %v, want 1", got)
+	}
+	if got := Weld(m, 0.01); got != 2 {
+		t.Errorf("Weld() = %v, want 2", got)
+	}
+	want := &go3mf.Mesh{
+		Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 1, 0}},
+		Triangles: []go3mf.Triangle{
+			go3mf.NewTrianglePID(0, 1, 2, 1, 0, 1, 2),
+			go3mf.NewTrianglePID(1, 3, 2, 1, 3, 4, 5),
+		},
+	}
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Weld() = %v", diff)
+	}
+}
+
+func TestRemoveDegenerate(t *testing.T) {
+	m := newCube()
+	m.Vertices = append(m.Vertices, go3mf.Point3D{0.5, 0, 0})
+	m.Triangles = append([]go3mf.Triangle{go3mf.NewTriangle(0, 8, 1), go3mf.NewTriangle(2, 2, 3)}, m.Triangles...)
+	m.TriangleSets = []go3mf.TriangleSet{{Identifier: "a", Refs: []uint32{0, 1, 2, 13}}}
+	if got := RemoveDegenerate(m); got != 2 {
+		t.Errorf("RemoveDegenerate() = %v, want 2", got)
+	}
+	if diff := deep.Equal(m.TriangleSets, []go3mf.TriangleSet{{Identifier: "a", Refs: []uint32{0, 11}}}); diff != nil {
+		t.Errorf("RemoveDegenerate() = %v", diff)
+	}
+	RemoveUnreferenced(m)
+	checkClosedCube(t, m)
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	m := newCube()
+	m.Triangles = append(m.Triangles, go3mf.NewTriangle(2, 1, 0), go3mf.NewTriangle(3, 2, 1))
+	if got := RemoveDuplicates(m); got != 2 {
+		t.Errorf("RemoveDuplicates() = %v, want 2", got)
+	}
+	checkClosedCube(t, m)
+}
+
+func TestOrient(t *testing.T) {
+	inverted := newCube()
+	for i := range inverted.Triangles {
+		inverted.Triangles[i] = flip(inverted.Triangles[i])
+	}
+	mixed := newCube()
+	mixed.Triangles[0] = go3mf.NewTrianglePID(0, 1, 2, 3, 4, 5, 6)
+	mixed.Triangles[7] = flip(mixed.Triangles[7])
+	tests := []struct {
+		name string
+		m    *go3mf.Mesh
+		want int
+	}{
+		{"oriented", newCube(), 0},
+		{"inverted", inverted, 12},
+		{"mixed", mixed, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Orient(tt.m); got != tt.want {
+				t.Errorf("Orient() = %v, want %v", got, tt.want)
+			}
+			checkClosedCube(t, tt.m)
+		})
+	}
+	if got := mixed.Triangles[0]; got != go3mf.NewTrianglePID(0, 2, 1, 3, 4, 6, 5) {
+		t.Errorf("Orient() = %v, want properties to be kept", got)
+	}
+}
+
+func TestFillHoles(t *testing.T) {
+	m := newCube()
+	for _, i := range []int{2, 6, 11} {
+		v1, v2, v3 := m.Triangles[i].Indices()
+		m.Triangles[i] = go3mf.NewTrianglePID(v1, v2, v3, 7, 1, 1, 1)
+	}
+	// Remove the bottom face and half of the top face.
+	m.Triangles = append(m.Triangles[2:3], m.Triangles[4:]...)
+	if got := FillHoles(m, 3); got != 1 {
+		t.Errorf("FillHoles() = %v, want 1", got)
+	}
+	if got := m.Triangles[len(m.Triangles)-1]; got != go3mf.NewTrianglePID(5, 7, 6, 7, 1, 1, 1) {
+		t.Errorf("FillHoles() = %v", got)
+	}
+	if got := FillHoles(m, 0); got != 2 {
+		t.Errorf("FillHoles() = %v, want 2", got)
+	}
+	checkClosedCube(t, m)
+}
+
+func TestRemoveUnreferenced(t *testing.T) {
+	m := newCube()
+	m.Vertices = append([]go3mf.Point3D{{5, 5, 5}}, m.Vertices...)
+	for i, tr := range m.Triangles {
+		v1, v2, v3 := tr.Indices()
+		m.Triangles[i] = go3mf.NewTriangle(v1+1, v2+1, v3+1)
+	}
+	m.Vertices = append(m.Vertices, go3mf.Point3D{6, 6, 6})
+	if got := RemoveUnreferenced(m); got != 2 {
+		t.Errorf("RemoveUnreferenced() = %v, want 2", got)
+	}
+	if diff := deep.Equal(m, newCube()); diff != nil {
+		t.Errorf("RemoveUnreferenced() = %v", diff)
+	}
+}
+
+func TestRepair(t *testing.T) {
+	// Triangle soup with a flipped, a duplicated, a degenerate and a missing triangle.
+	cube := newCube()
+	m := new(go3mf.Mesh)
+	for i, tr := range cube.Triangles[1:] {
+		if i == 3 {
+			tr = flip(tr)
+		}
+		v1, v2, v3 := tr.Indices()
+		n := uint32(len(m.Vertices))
+		m.Vertices = append(m.Vertices, cube.Vertices[v1], cube.Vertices[v2], cube.Vertices[v3])
+		m.Triangles = append(m.Triangles, go3mf.NewTriangle(n, n+1, n+2))
+	}
+	m.Triangles = append(m.Triangles, m.Triangles[0], go3mf.NewTriangle(0, 0, 1))
+	want := []Report{
+		{StepWeld, 25},
+		{StepRemoveDegenerate, 1},
+		{StepRemoveDuplicates, 1},
+		{StepOrient, 1},
+		{StepFillHoles, 1},
+		{StepRemoveUnreferenced, 0},
+	}
+	if diff := deep.Equal(Repair(m, Options{Tolerance: 1e-5}), want); diff != nil {
+		t.Errorf("Repair() = %v", diff)
+	}
+	checkClosedCube(t, m)
+}
+
+func TestStep_String(t *testing.T) {
+	if got := StepFillHoles.String(); got != "fill holes" {
+		t.Errorf("Step.String() = %v", got)
+	}
+}
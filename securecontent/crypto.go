@@ -0,0 +1,340 @@
+package securecontent
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+const (
+	keySize = 32
+	ivSize  = 12
+	tagSize = 16
+)
+
+// A KeyProvider wraps and unwraps the content encryption keys
+// using the key encryption key of each consumer.
+type KeyProvider interface {
+	// WrapKey encrypts the content encryption key for the consumer.
+	WrapKey(c *Consumer, params KEKParams, key []byte) ([]byte, error)
+	// UnwrapKey decrypts the content encryption key of the consumer.
+	// It must return ErrUnknownConsumer if it does not hold the consumer key.
+	UnwrapKey(c *Consumer, params KEKParams, cipher []byte) ([]byte, error)
+}
+
+// LocalKeyProvider is a KeyProvider backed by an in-memory RSA private key.
+// Consumers with a PEM public key in KeyValue can also be wrapped.
+type LocalKeyProvider struct {
+	ConsumerID string
+	Key        *rsa.PrivateKey
+}
+
+// NewLocalKeyProvider returns a LocalKeyProvider for the consumer
+// using a PEM encoded PKCS#1 or PKCS#8 RSA private key.
+func NewLocalKeyProvider(consumerID string, pemKey []byte) (*LocalKeyProvider, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return &LocalKeyProvider{ConsumerID: consumerID, Key: key}, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("PEM private key is not a RSA key")
+	}
+	return &LocalKeyProvider{ConsumerID: consumerID, Key: rsaKey}, nil
+}
+
+// WrapKey encrypts the content encryption key using RSA-OAEP.
+func (p *LocalKeyProvider) WrapKey(c *Consumer, params KEKParams, key []byte) ([]byte, error) {
+	h, err := oaepHash(params)
+	if err != nil {
+		return nil, err
+	}
+	var pub *rsa.PublicKey
+	if c.ID == p.ConsumerID {
+		pub = &p.Key.PublicKey
+	} else if c.KeyValue != "" {
+		if pub, err = parsePublicKey([]byte(c.KeyValue)); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, ErrUnknownConsumer
+	}
+	return rsa.EncryptOAEP(h, rand.Reader, pub, key, nil)
+}
+
+// UnwrapKey decrypts the content encryption key using RSA-OAEP.
+func (p *LocalKeyProvider) UnwrapKey(c *Consumer, params KEKParams, cipher []byte) ([]byte, error) {
+	if c.ID != p.ConsumerID {
+		return nil, ErrUnknownConsumer
+	}
+	h, err := oaepHash(params)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.DecryptOAEP(h, nil, p.Key, cipher, nil)
+}
+
+func parsePublicKey(pemKey []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("invalid PEM public key")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("PEM public key is not a RSA key")
+	}
+	return rsaKey, nil
+}
+
+// oaepHash returns the hash defined by the params.
+// The standard library uses the same hash for the digest and the mask generation,
+// so params using different ones are not supported.
+func oaepHash(params KEKParams) (hash.Hash, error) {
+	if params.WrappingAlgorithm != "" && params.WrappingAlgorithm != WrappingRSAOAEP {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	digests := map[string]func() hash.Hash{
+		"":           sha1.New,
+		DigestSHA1:   sha1.New,
+		DigestSHA256: sha256.New,
+		DigestSHA384: sha512.New384,
+		DigestSHA512: sha512.New,
+	}
+	mgfs := map[string]string{
+		"":         DigestSHA1,
+		MGF1SHA1:   DigestSHA1,
+		MGF1SHA256: DigestSHA256,
+		MGF1SHA384: DigestSHA384,
+		MGF1SHA512: DigestSHA512,
+	}
+	digest, ok := digests[params.DigestMethod]
+	if !ok {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	mgf, ok := mgfs[params.MGFAlgorithm]
+	if !ok || digests[mgf]().Size() != digest().Size() {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return digest(), nil
+}
+
+// Decrypter implements go3mf.Decrypter.
+// It is safe to use it from multiple goroutines.
+type Decrypter struct {
+	KeyStore    KeyStore
+	KeyProvider KeyProvider
+	mu          sync.Mutex
+	keys        map[int][]byte
+}
+
+// NewDecrypter returns a new Decrypter that unwraps the keys using kp.
+func NewDecrypter(kp KeyProvider) *Decrypter {
+	return &Decrypter{KeyProvider: kp}
+}
+
+// ReadKeyStore decodes the key store from r.
+func (d *Decrypter) ReadKeyStore(r io.Reader) error {
+	return d.KeyStore.Decode(r)
+}
+
+// Decrypt returns the plain content of the named part.
+func (d *Decrypter) Decrypt(name string, r io.ReadCloser) (io.ReadCloser, error) {
+	group, rd, ok := d.KeyStore.FindResourceData(name)
+	if !ok {
+		return r, nil
+	}
+	defer r.Close()
+	if rd.CEKParams.EncryptionAlgorithm != "" && rd.CEKParams.EncryptionAlgorithm != EncryptionAES256GCM {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	key, err := d.key(group)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rd.CEKParams.IV) != aead.NonceSize() || len(rd.CEKParams.Tag) != tagSize {
+		return nil, ErrInvalidCEKParams
+	}
+	data, err = aead.Open(nil, rd.CEKParams.IV, append(data, rd.CEKParams.Tag...), rd.CEKParams.AAD)
+	if err != nil {
+		return nil, err
+	}
+	if rd.CEKParams.Compression == CompressionDeflate {
+		return flate.NewReader(bytes.NewReader(data)), nil
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (d *Decrypter) key(group int) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if key, ok := d.keys[group]; ok {
+		return key, nil
+	}
+	for i := range d.KeyStore.ResourceDataGroups[group].AccessRights {
+		ar := &d.KeyStore.ResourceDataGroups[group].AccessRights[i]
+		c, err := d.KeyStore.consumer(ar)
+		if err != nil {
+			return nil, err
+		}
+		key, err := d.KeyProvider.UnwrapKey(c, ar.KEKParams, ar.CipherValue)
+		if err == ErrUnknownConsumer {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if d.keys == nil {
+			d.keys = make(map[int][]byte)
+		}
+		d.keys[group] = key
+		return key, nil
+	}
+	return nil, ErrNoAccessRight
+}
+
+// Encrypter implements go3mf.Encrypter.
+// The content encryption key of each group is randomly generated
+// and wrapped for all the consumers with an access right.
+type Encrypter struct {
+	KeyStore    *KeyStore
+	KeyProvider KeyProvider
+	keys        map[int][]byte
+}
+
+// NewEncrypter returns a new Encrypter that protects the parts
+// listed in ks and wraps the keys using kp.
+func NewEncrypter(ks *KeyStore, kp KeyProvider) *Encrypter {
+	return &Encrypter{KeyStore: ks, KeyProvider: kp}
+}
+
+// Encrypt returns a writer that encrypts the named part on Close,
+// or nil if the part is not listed in the key store.
+func (e *Encrypter) Encrypt(name string, w io.Writer) (io.WriteCloser, error) {
+	group, rd, ok := e.KeyStore.FindResourceData(name)
+	if !ok {
+		return nil, nil
+	}
+	if rd.CEKParams.EncryptionAlgorithm == "" {
+		rd.CEKParams.EncryptionAlgorithm = EncryptionAES256GCM
+	} else if rd.CEKParams.EncryptionAlgorithm != EncryptionAES256GCM {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	key, err := e.key(group)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	ew := &encryptWriter{w: w, aead: aead, params: &rd.CEKParams}
+	if rd.CEKParams.Compression == CompressionDeflate {
+		ew.fw, _ = flate.NewWriter(&ew.buf, flate.DefaultCompression)
+	}
+	return ew, nil
+}
+
+// WriteKeyStore encodes the key store into w.
+func (e *Encrypter) WriteKeyStore(w io.Writer) error {
+	return e.KeyStore.Encode(w)
+}
+
+func (e *Encrypter) key(group int) ([]byte, error) {
+	if key, ok := e.keys[group]; ok {
+		return key, nil
+	}
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	for i := range e.KeyStore.ResourceDataGroups[group].AccessRights {
+		ar := &e.KeyStore.ResourceDataGroups[group].AccessRights[i]
+		c, err := e.KeyStore.consumer(ar)
+		if err != nil {
+			return nil, err
+		}
+		if ar.CipherValue, err = e.KeyProvider.WrapKey(c, ar.KEKParams, key); err != nil {
+			return nil, err
+		}
+	}
+	if e.keys == nil {
+		e.keys = make(map[int][]byte)
+	}
+	e.keys[group] = key
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCMWithTagSize(block, tagSize)
+}
+
+type encryptWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	params *CEKParams
+	buf    bytes.Buffer
+	fw     *flate.Writer
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	if w.fw != nil {
+		return w.fw.Write(p)
+	}
+	return w.buf.Write(p)
+}
+
+func (w *encryptWriter) Close() error {
+	if w.fw != nil {
+		if err := w.fw.Close(); err != nil {
+			return err
+		}
+	}
+	iv := make([]byte, ivSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return err
+	}
+	data := w.aead.Seal(nil, iv, w.buf.Bytes(), w.params.AAD)
+	w.params.IV = iv
+	w.params.Tag = data[len(data)-tagSize:]
+	_, err := w.w.Write(data[:len(data)-tagSize])
+	return err
+}
@@ -0,0 +1,187 @@
+package securecontent
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+var (
+	testKeysOnce sync.Once
+	testKeys     [2]*rsa.PrivateKey
+)
+
+func testKey(t *testing.T, i int) *rsa.PrivateKey {
+	testKeysOnce.Do(func() {
+		for j := range testKeys {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				t.Fatalf("rsa.GenerateKey() error = %v", err)
+			}
+			testKeys[j] = key
+		}
+	})
+	return testKeys[i]
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	key := testKey(t, 0)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	tests := []struct {
+		name    string
+		pemKey  []byte
+		wantErr bool
+	}{
+		{"pkcs1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), false},
+		{"pkcs8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), false},
+		{"invalidPEM", []byte("key"), true},
+		{"invalidKey", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLocalKeyProvider("test", tt.pemKey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewLocalKeyProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.ConsumerID != "test" || got.Key.N.Cmp(key.N) != 0) {
+				t.Errorf("NewLocalKeyProvider() = %v", got)
+			}
+		})
+	}
+}
+
+func Test_oaepHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   KEKParams
+		wantSize int
+		wantErr  bool
+	}{
+		{"default", KEKParams{}, 20, false},
+		{"sha256", KEKParams{WrappingAlgorithm: WrappingRSAOAEP, MGFAlgorithm: MGF1SHA256, DigestMethod: DigestSHA256}, 32, false},
+		{"sha384", KEKParams{MGFAlgorithm: MGF1SHA384, DigestMethod: DigestSHA384}, 48, false},
+		{"sha512", KEKParams{MGFAlgorithm: MGF1SHA512, DigestMethod: DigestSHA512}, 64, false},
+		{"mixed", KEKParams{MGFAlgorithm: MGF1SHA1, DigestMethod: DigestSHA256}, 0, true},
+		{"wrapping", KEKParams{WrappingAlgorithm: "other"}, 0, true},
+		{"digest", KEKParams{DigestMethod: "other"}, 0, true},
+		{"mgf", KEKParams{MGFAlgorithm: "other"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oaepHash(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("oaepHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Size() != tt.wantSize {
+				t.Errorf("oaepHash() size = %v, want %v", got.Size(), tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestEncrypter_Decrypter(t *testing.T) {
+	other := testKey(t, 1)
+	otherPub, _ := x509.MarshalPKIXPublicKey(&other.PublicKey)
+	newKeyStore := func() *KeyStore {
+		return &KeyStore{
+			UUID: "b7aa9c0b-4bca-4f1e-a9f2-0ad4d0a7a1b4",
+			Consumers: []Consumer{
+				{ID: "test"},
+				{ID: "other", KeyValue: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: otherPub}))},
+			},
+			ResourceDataGroups: []ResourceDataGroup{
+				{
+					KeyUUID:      "1b2f2a8e-0a8c-4a6a-a2a2-3f2f6b7c2a11",
+					AccessRights: []AccessRight{{ConsumerIndex: 0}, {ConsumerIndex: 1, KEKParams: KEKParams{MGFAlgorithm: MGF1SHA256, DigestMethod: DigestSHA256}}},
+					ResourceDatas: []ResourceData{
+						{Path: "/3D/other.model", CEKParams: CEKParams{Compression: CompressionDeflate}},
+						{Path: "/3D/Textures/a.png", CEKParams: CEKParams{AAD: []byte("aad")}},
+					},
+				},
+				{
+					KeyUUID:       "4a0c4c5e-57d4-4f43-9a3c-2b1d7e5a9d11",
+					AccessRights:  []AccessRight{{ConsumerIndex: 0}},
+					ResourceDatas: []ResourceData{{Path: "/3D/3dmodel.model"}},
+				},
+			},
+		}
+	}
+	m := &go3mf.Model{
+		Path:       go3mf.DefaultModelPath,
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{Objects: []*go3mf.Object{
+			{ID: 1, Components: []*go3mf.Component{{ObjectID: 2}}},
+		}},
+		Attachments: []go3mf.Attachment{
+			{ContentType: "image/png", Path: "/3D/Textures/a.png", Stream: bytes.NewBufferString("texture")},
+		},
+		Relationships: []go3mf.Relationship{
+			{Path: "/3D/Textures/a.png", Type: "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dtexture", ID: "1"},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/other.model": {Resources: go3mf.Resources{Objects: []*go3mf.Object{
+				{ID: 2, Mesh: &go3mf.Mesh{Vertices: []go3mf.Point3D{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}}, Triangles: []go3mf.Triangle{}}},
+			}}},
+		},
+	}
+	ks := newKeyStore()
+	local := &LocalKeyProvider{ConsumerID: "test", Key: testKey(t, 0)}
+	buff := new(bytes.Buffer)
+	enc := go3mf.NewEncoder(buff)
+	enc.Encrypter = NewEncrypter(ks, local)
+	if err := enc.Encode(m); err != nil {
+		t.Fatalf("Encoder.Encode() error = %v", err)
+	}
+	for _, rd := range []ResourceData{ks.ResourceDataGroups[0].ResourceDatas[0], ks.ResourceDataGroups[1].ResourceDatas[0]} {
+		if len(rd.CEKParams.IV) != ivSize || len(rd.CEKParams.Tag) != tagSize || rd.CEKParams.EncryptionAlgorithm != EncryptionAES256GCM {
+			t.Fatalf("Encrypter.Encrypt() cekparams = %v", rd.CEKParams)
+		}
+	}
+	m.Attachments[0].Stream = bytes.NewBufferString("texture")
+
+	tests := []struct {
+		name    string
+		kp      KeyProvider
+		wantErr bool
+	}{
+		{"owner", local, false},
+		{"other", &LocalKeyProvider{ConsumerID: "other", Key: other}, true},
+		{"unknown", &LocalKeyProvider{ConsumerID: "unknown", Key: other}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := go3mf.NewDecoder(bytes.NewReader(buff.Bytes()), int64(buff.Len()))
+			dec.Decrypter = NewDecrypter(tt.kp)
+			dec.EagerAttachments = true
+			got := new(go3mf.Model)
+			err := dec.Decode(got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decoder.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := deep.Equal(got, m); diff != nil {
+				t.Errorf("Decoder.Decode() = %v", diff)
+			}
+		})
+	}
+
+	t.Run("otherGroup", func(t *testing.T) {
+		d := NewDecrypter(&LocalKeyProvider{ConsumerID: "other", Key: other})
+		d.KeyStore = *ks
+		if _, err := d.key(0); err != nil {
+			t.Errorf("Decrypter.key() error = %v", err)
+		}
+		if _, err := d.key(1); err != ErrNoAccessRight {
+			t.Errorf("Decrypter.key() error = %v, want %v", err, ErrNoAccessRight)
+		}
+	})
+}
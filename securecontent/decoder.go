@@ -0,0 +1,135 @@
+package securecontent
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	specerr "github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (Spec) CreateElementDecoder(interface{}, string) spec.ElementDecoder {
+	return nil
+}
+
+type xmlKeyStore struct {
+	XMLName            xml.Name               `xml:"http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07 keystore"`
+	UUID               string                 `xml:"UUID,attr"`
+	Consumers          []xmlConsumer          `xml:"consumer"`
+	ResourceDataGroups []xmlResourceDataGroup `xml:"resourcedatagroup"`
+}
+
+type xmlConsumer struct {
+	ID       string `xml:"consumerid,attr"`
+	KeyID    string `xml:"keyid,attr,omitempty"`
+	KeyValue string `xml:"keyvalue,omitempty"`
+}
+
+type xmlResourceDataGroup struct {
+	KeyUUID       string            `xml:"keyuuid,attr"`
+	AccessRights  []xmlAccessRight  `xml:"accessright"`
+	ResourceDatas []xmlResourceData `xml:"resourcedata"`
+}
+
+type xmlAccessRight struct {
+	ConsumerIndex string `xml:"consumerindex,attr"`
+	KEKParams     struct {
+		WrappingAlgorithm string `xml:"wrappingalgorithm,attr"`
+		MGFAlgorithm      string `xml:"mgfalgorithm,attr,omitempty"`
+		DigestMethod      string `xml:"digestmethod,attr,omitempty"`
+	} `xml:"kekparams"`
+	CipherData struct {
+		CipherValue string `xml:"http://www.w3.org/2001/04/xmlenc# CipherValue"`
+	} `xml:"cipherdata"`
+}
+
+type xmlResourceData struct {
+	Path      string `xml:"path,attr"`
+	CEKParams struct {
+		EncryptionAlgorithm string `xml:"encryptionalgorithm,attr"`
+		Compression         string `xml:"compression,attr,omitempty"`
+		IV                  string `xml:"iv"`
+		Tag                 string `xml:"tag"`
+		AAD                 string `xml:"aad,omitempty"`
+	} `xml:"cekparams"`
+}
+
+// Decode reads the XML encoding of a key store from r.
+func (ks *KeyStore) Decode(r io.Reader) error {
+	var x xmlKeyStore
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return err
+	}
+	var errs error
+	ks.UUID = x.UUID
+	ks.Consumers = make([]Consumer, len(x.Consumers))
+	for i, c := range x.Consumers {
+		ks.Consumers[i] = Consumer{ID: c.ID, KeyID: c.KeyID, KeyValue: strings.TrimSpace(c.KeyValue)}
+	}
+	ks.ResourceDataGroups = make([]ResourceDataGroup, len(x.ResourceDataGroups))
+	for i, xg := range x.ResourceDataGroups {
+		var gErrs error
+		group := ResourceDataGroup{KeyUUID: xg.KeyUUID}
+		group.AccessRights = make([]AccessRight, len(xg.AccessRights))
+		for j, xa := range xg.AccessRights {
+			var aErrs error
+			ar := &group.AccessRights[j]
+			idx, err := strconv.ParseUint(xa.ConsumerIndex, 10, 32)
+			if err != nil {
+				aErrs = specerr.Append(aErrs, specerr.NewParseAttrError(attrConsumerIndex, true))
+			}
+			ar.ConsumerIndex = uint32(idx)
+			ar.KEKParams = KEKParams{
+				WrappingAlgorithm: xa.KEKParams.WrappingAlgorithm,
+				MGFAlgorithm:      xa.KEKParams.MGFAlgorithm,
+				DigestMethod:      xa.KEKParams.DigestMethod,
+			}
+			if ar.CipherValue, err = decodeBase64(xa.CipherData.CipherValue); err != nil {
+				aErrs = specerr.Append(aErrs, specerr.NewParseAttrError(attrCipherValue, true))
+			}
+			gErrs = specerr.Append(gErrs, specerr.WrapIndex(aErrs, ar, j))
+		}
+		group.ResourceDatas = make([]ResourceData, len(xg.ResourceDatas))
+		for j, xr := range xg.ResourceDatas {
+			var rErrs error
+			rd := &group.ResourceDatas[j]
+			rd.Path = xr.Path
+			rd.CEKParams.EncryptionAlgorithm = xr.CEKParams.EncryptionAlgorithm
+			if xr.CEKParams.Compression != "" {
+				var ok bool
+				if rd.CEKParams.Compression, ok = newCompression(xr.CEKParams.Compression); !ok {
+					rErrs = specerr.Append(rErrs, specerr.NewParseAttrError(attrCompression, false))
+				}
+			}
+			var err error
+			if rd.CEKParams.IV, err = decodeBase64(xr.CEKParams.IV); err != nil {
+				rErrs = specerr.Append(rErrs, specerr.NewParseAttrError(attrIV, true))
+			}
+			if rd.CEKParams.Tag, err = decodeBase64(xr.CEKParams.Tag); err != nil {
+				rErrs = specerr.Append(rErrs, specerr.NewParseAttrError(attrTag, true))
+			}
+			if rd.CEKParams.AAD, err = decodeBase64(xr.CEKParams.AAD); err != nil {
+				rErrs = specerr.Append(rErrs, specerr.NewParseAttrError(attrAAD, false))
+			}
+			gErrs = specerr.Append(gErrs, specerr.WrapIndex(rErrs, rd, j))
+		}
+		ks.ResourceDataGroups[i] = group
+		errs = specerr.Append(errs, specerr.WrapIndex(gErrs, &ks.ResourceDataGroups[i], i))
+	}
+	return errs
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
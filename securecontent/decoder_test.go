@@ -0,0 +1,119 @@
+package securecontent
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestKeyStore_Decode(t *testing.T) {
+	want := &KeyStore{
+		UUID: "b7aa9c0b-4bca-4f1e-a9f2-0ad4d0a7a1b4",
+		Consumers: []Consumer{
+			{ID: "HP#MOP44B#SG5693454", KeyID: "HP-3DP-1", KeyValue: "-----BEGIN PUBLIC KEY-----"},
+			{ID: "other"},
+		},
+		ResourceDataGroups: []ResourceDataGroup{{
+			KeyUUID: "1b2f2a8e-0a8c-4a6a-a2a2-3f2f6b7c2a11",
+			AccessRights: []AccessRight{{
+				ConsumerIndex: 1,
+				KEKParams:     KEKParams{WrappingAlgorithm: WrappingRSAOAEP, MGFAlgorithm: MGF1SHA256, DigestMethod: DigestSHA256},
+				CipherValue:   []byte("cipher"),
+			}},
+			ResourceDatas: []ResourceData{{
+				Path: "/3D/3dmodel.model",
+				CEKParams: CEKParams{
+					EncryptionAlgorithm: EncryptionAES256GCM,
+					Compression:         CompressionDeflate,
+					IV:                  []byte("iv"),
+					Tag:                 []byte("tag"),
+					AAD:                 []byte("aad"),
+				},
+			}},
+		}},
+	}
+	keystore := `<?xml version="1.0" encoding="UTF-8"?>
+		<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07" xmlns:xenc="http://www.w3.org/2001/04/xmlenc#" UUID="b7aa9c0b-4bca-4f1e-a9f2-0ad4d0a7a1b4">
+			<consumer consumerid="HP#MOP44B#SG5693454" keyid="HP-3DP-1">
+				<keyvalue>
+					-----BEGIN PUBLIC KEY-----
+				</keyvalue>
+			</consumer>
+			<consumer consumerid="other"/>
+			<resourcedatagroup keyuuid="1b2f2a8e-0a8c-4a6a-a2a2-3f2f6b7c2a11">
+				<accessright consumerindex="1">
+					<kekparams wrappingalgorithm="http://www.w3.org/2009/xmlenc11#rsa-oaep" mgfalgorithm="http://www.w3.org/2009/xmlenc11#mgf1sha256" digestmethod="http://www.w3.org/2001/04/xmlenc#sha256"/>
+					<cipherdata>
+						<xenc:CipherValue>Y2lwaGVy</xenc:CipherValue>
+					</cipherdata>
+				</accessright>
+				<resourcedata path="/3D/3dmodel.model">
+					<cekparams encryptionalgorithm="http://www.w3.org/2009/xmlenc11#aes256-gcm" compression="deflate">
+						<iv>aXY=</iv>
+						<tag>dGFn</tag>
+						<aad>YWFk</aad>
+					</cekparams>
+				</resourcedata>
+			</resourcedatagroup>
+		</keystore>`
+
+	t.Run("base", func(t *testing.T) {
+		got := new(KeyStore)
+		if err := got.Decode(strings.NewReader(keystore)); err != nil {
+			t.Fatalf("KeyStore.Decode() unexpected error = %v", err)
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("KeyStore.Decode() = %v", diff)
+		}
+	})
+}
+
+func TestKeyStore_Decode_warns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("ResourceDataGroup#0@AccessRight#0: %v", errors.NewParseAttrError(attrConsumerIndex, true)),
+		fmt.Sprintf("ResourceDataGroup#0@AccessRight#0: %v", errors.NewParseAttrError(attrCipherValue, true)),
+		fmt.Sprintf("ResourceDataGroup#0@ResourceData#0: %v", errors.NewParseAttrError(attrCompression, false)),
+		fmt.Sprintf("ResourceDataGroup#0@ResourceData#0: %v", errors.NewParseAttrError(attrIV, true)),
+		fmt.Sprintf("ResourceDataGroup#0@ResourceData#0: %v", errors.NewParseAttrError(attrTag, true)),
+		fmt.Sprintf("ResourceDataGroup#0@ResourceData#0: %v", errors.NewParseAttrError(attrAAD, false)),
+	}
+	keystore := `
+		<keystore xmlns="http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07" xmlns:xenc="http://www.w3.org/2001/04/xmlenc#">
+			<resourcedatagroup keyuuid="1b2f2a8e-0a8c-4a6a-a2a2-3f2f6b7c2a11">
+				<accessright consumerindex="a">
+					<kekparams wrappingalgorithm="http://www.w3.org/2009/xmlenc11#rsa-oaep"/>
+					<cipherdata><xenc:CipherValue>*</xenc:CipherValue></cipherdata>
+				</accessright>
+				<resourcedata path="/3D/3dmodel.model">
+					<cekparams encryptionalgorithm="http://www.w3.org/2009/xmlenc11#aes256-gcm" compression="other">
+						<iv>*</iv>
+						<tag>*</tag>
+						<aad>*</aad>
+					</cekparams>
+				</resourcedata>
+			</resourcedatagroup>
+		</keystore>`
+
+	t.Run("base", func(t *testing.T) {
+		err := new(KeyStore).Decode(strings.NewReader(keystore))
+		if err == nil {
+			t.Fatal("error expected")
+		}
+		var errs []string
+		for _, err := range err.(*errors.List).Errors {
+			errs = append(errs, err.Error())
+		}
+		if diff := deep.Equal(errs, want); diff != nil {
+			t.Errorf("KeyStore.Decode() = %v", diff)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		if err := new(KeyStore).Decode(strings.NewReader("<keystore>")); err == nil {
+			t.Error("error expected")
+		}
+	})
+}
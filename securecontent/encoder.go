@@ -0,0 +1,53 @@
+package securecontent
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// Encode writes the XML encoding of the key store to w.
+func (ks *KeyStore) Encode(w io.Writer) error {
+	x := xmlKeyStore{UUID: ks.UUID}
+	x.Consumers = make([]xmlConsumer, len(ks.Consumers))
+	for i, c := range ks.Consumers {
+		x.Consumers[i] = xmlConsumer{ID: c.ID, KeyID: c.KeyID, KeyValue: c.KeyValue}
+	}
+	x.ResourceDataGroups = make([]xmlResourceDataGroup, len(ks.ResourceDataGroups))
+	for i, group := range ks.ResourceDataGroups {
+		xg := &x.ResourceDataGroups[i]
+		xg.KeyUUID = group.KeyUUID
+		xg.AccessRights = make([]xmlAccessRight, len(group.AccessRights))
+		for j, ar := range group.AccessRights {
+			xa := &xg.AccessRights[j]
+			xa.ConsumerIndex = strconv.FormatUint(uint64(ar.ConsumerIndex), 10)
+			xa.KEKParams.WrappingAlgorithm = ar.KEKParams.WrappingAlgorithm
+			if xa.KEKParams.WrappingAlgorithm == "" {
+				xa.KEKParams.WrappingAlgorithm = WrappingRSAOAEP
+			}
+			xa.KEKParams.MGFAlgorithm = ar.KEKParams.MGFAlgorithm
+			xa.KEKParams.DigestMethod = ar.KEKParams.DigestMethod
+			xa.CipherData.CipherValue = base64.StdEncoding.EncodeToString(ar.CipherValue)
+		}
+		xg.ResourceDatas = make([]xmlResourceData, len(group.ResourceDatas))
+		for j, rd := range group.ResourceDatas {
+			xr := &xg.ResourceDatas[j]
+			xr.Path = rd.Path
+			xr.CEKParams.EncryptionAlgorithm = rd.CEKParams.EncryptionAlgorithm
+			if xr.CEKParams.EncryptionAlgorithm == "" {
+				xr.CEKParams.EncryptionAlgorithm = EncryptionAES256GCM
+			}
+			if rd.CEKParams.Compression != CompressionNone {
+				xr.CEKParams.Compression = rd.CEKParams.Compression.String()
+			}
+			xr.CEKParams.IV = base64.StdEncoding.EncodeToString(rd.CEKParams.IV)
+			xr.CEKParams.Tag = base64.StdEncoding.EncodeToString(rd.CEKParams.Tag)
+			xr.CEKParams.AAD = base64.StdEncoding.EncodeToString(rd.CEKParams.AAD)
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(&x)
+}
@@ -0,0 +1,45 @@
+package securecontent
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestKeyStore_Encode(t *testing.T) {
+	ks := &KeyStore{
+		UUID: "b7aa9c0b-4bca-4f1e-a9f2-0ad4d0a7a1b4",
+		Consumers: []Consumer{
+			{ID: "HP#MOP44B#SG5693454", KeyID: "HP-3DP-1", KeyValue: "-----BEGIN PUBLIC KEY-----"},
+			{ID: "other"},
+		},
+		ResourceDataGroups: []ResourceDataGroup{{
+			KeyUUID: "1b2f2a8e-0a8c-4a6a-a2a2-3f2f6b7c2a11",
+			AccessRights: []AccessRight{
+				{ConsumerIndex: 0, KEKParams: KEKParams{WrappingAlgorithm: WrappingRSAOAEP}, CipherValue: []byte("cipher1")},
+				{ConsumerIndex: 1, KEKParams: KEKParams{WrappingAlgorithm: WrappingRSAOAEP, MGFAlgorithm: MGF1SHA512, DigestMethod: DigestSHA512}, CipherValue: []byte("cipher2")},
+			},
+			ResourceDatas: []ResourceData{
+				{Path: "/3D/3dmodel.model", CEKParams: CEKParams{EncryptionAlgorithm: EncryptionAES256GCM, IV: []byte("iv"), Tag: []byte("tag")}},
+				{Path: "/3D/Textures/a.png", CEKParams: CEKParams{
+					EncryptionAlgorithm: EncryptionAES256GCM, Compression: CompressionDeflate, IV: []byte("iv"), Tag: []byte("tag"), AAD: []byte("aad"),
+				}},
+			},
+		}},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ks.Encode(&buf); err != nil {
+			t.Fatalf("KeyStore.Encode() error = %v", err)
+		}
+		got := new(KeyStore)
+		if err := got.Decode(&buf); err != nil {
+			t.Fatalf("KeyStore.Encode() error decoding = %v, s = %s", err, buf.String())
+		}
+		if diff := deep.Equal(got, ks); diff != nil {
+			t.Errorf("KeyStore.Encode() = %v, s = %s", diff, buf.String())
+		}
+	})
+}
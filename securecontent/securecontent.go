@@ -0,0 +1,152 @@
+package securecontent
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+)
+
+// Namespace is the canonical name of this extension.
+const Namespace = "http://schemas.microsoft.com/3dmanufacturing/securecontent/2019/07"
+
+// Supported algorithms.
+const (
+	EncryptionAES256GCM = "http://www.w3.org/2009/xmlenc11#aes256-gcm"
+	WrappingRSAOAEP     = "http://www.w3.org/2009/xmlenc11#rsa-oaep"
+	MGF1SHA1            = "http://www.w3.org/2009/xmlenc11#mgf1sha1"
+	MGF1SHA256          = "http://www.w3.org/2009/xmlenc11#mgf1sha256"
+	MGF1SHA384          = "http://www.w3.org/2009/xmlenc11#mgf1sha384"
+	MGF1SHA512          = "http://www.w3.org/2009/xmlenc11#mgf1sha512"
+	DigestSHA1          = "http://www.w3.org/2000/09/xmldsig#sha1"
+	DigestSHA256        = "http://www.w3.org/2001/04/xmlenc#sha256"
+	DigestSHA384        = "http://www.w3.org/2001/04/xmldsig-more#sha384"
+	DigestSHA512        = "http://www.w3.org/2001/04/xmlenc#sha512"
+)
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "sc",
+	IsRequired: false,
+}
+
+var (
+	ErrConsumerIndex        = errors.New("consumerindex MUST reference an existing consumer")
+	ErrUnsupportedAlgorithm = errors.New("the algorithm is not supported")
+	ErrUnknownConsumer      = errors.New("there is no key available for the consumer")
+	ErrNoAccessRight        = errors.New("none of the access rights can be unwrapped with the available keys")
+	ErrInvalidCEKParams     = errors.New("iv MUST be 96 bits long and tag MUST be 128 bits long")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+type Spec struct{}
+
+// Compression defines the compression applied to the content before encrypting it.
+type Compression uint8
+
+// Supported compressions.
+const (
+	CompressionNone Compression = iota
+	CompressionDeflate
+)
+
+func newCompression(s string) (c Compression, ok bool) {
+	c, ok = map[string]Compression{
+		"none":    CompressionNone,
+		"deflate": CompressionDeflate,
+	}[s]
+	return
+}
+
+func (c Compression) String() string {
+	return map[Compression]string{
+		CompressionNone:    "none",
+		CompressionDeflate: "deflate",
+	}[c]
+}
+
+// Consumer is an entity that can access the encrypted content.
+// KeyValue optionally contains the PEM encoded public key of the consumer.
+type Consumer struct {
+	ID       string
+	KeyID    string
+	KeyValue string
+}
+
+// KEKParams defines the algorithms used to wrap the content encryption key.
+// Empty fields mean the spec defaults.
+type KEKParams struct {
+	WrappingAlgorithm string
+	MGFAlgorithm      string
+	DigestMethod      string
+}
+
+// AccessRight contains the content encryption key wrapped
+// with the key of the consumer.
+type AccessRight struct {
+	ConsumerIndex uint32
+	KEKParams     KEKParams
+	CipherValue   []byte
+}
+
+// CEKParams defines how a part is encrypted with the content encryption key.
+type CEKParams struct {
+	EncryptionAlgorithm string
+	Compression         Compression
+	IV                  []byte
+	Tag                 []byte
+	AAD                 []byte
+}
+
+// ResourceData identifies an encrypted part.
+type ResourceData struct {
+	Path      string
+	CEKParams CEKParams
+}
+
+// ResourceDataGroup groups the parts that share the same content encryption key.
+type ResourceDataGroup struct {
+	KeyUUID       string
+	AccessRights  []AccessRight
+	ResourceDatas []ResourceData
+}
+
+// KeyStore contains the information needed to decrypt the protected parts of a package.
+type KeyStore struct {
+	UUID               string
+	Consumers          []Consumer
+	ResourceDataGroups []ResourceDataGroup
+}
+
+// FindResourceData returns the resource data of the part
+// and the index of the group it belongs to.
+func (ks *KeyStore) FindResourceData(path string) (int, *ResourceData, bool) {
+	for i := range ks.ResourceDataGroups {
+		group := &ks.ResourceDataGroups[i]
+		for j := range group.ResourceDatas {
+			if strings.EqualFold(group.ResourceDatas[j].Path, path) {
+				return i, &group.ResourceDatas[j], true
+			}
+		}
+	}
+	return 0, nil, false
+}
+
+func (ks *KeyStore) consumer(ar *AccessRight) (*Consumer, error) {
+	if int(ar.ConsumerIndex) >= len(ks.Consumers) {
+		return nil, ErrConsumerIndex
+	}
+	return &ks.Consumers[ar.ConsumerIndex], nil
+}
+
+const (
+	attrConsumerIndex = "consumerindex"
+	attrCipherValue   = "CipherValue"
+	attrCompression   = "compression"
+	attrIV            = "iv"
+	attrTag           = "tag"
+	attrAAD           = "aad"
+)
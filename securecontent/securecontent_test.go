@@ -0,0 +1,78 @@
+package securecontent
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+)
+
+var _ go3mf.Decrypter = new(Decrypter)
+var _ go3mf.Encrypter = new(Encrypter)
+var _ KeyProvider = new(LocalKeyProvider)
+
+func TestCompression_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Compression
+	}{
+		{"none", CompressionNone},
+		{"deflate", CompressionDeflate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.name {
+				t.Errorf("Compression.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newCompression(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantC  Compression
+		wantOk bool
+	}{
+		{"none", CompressionNone, true},
+		{"deflate", CompressionDeflate, true},
+		{"empty", CompressionNone, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, gotOk := newCompression(tt.name)
+			if !reflect.DeepEqual(gotC, tt.wantC) {
+				t.Errorf("newCompression() gotC = %v, want %v", gotC, tt.wantC)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newCompression() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestKeyStore_FindResourceData(t *testing.T) {
+	ks := &KeyStore{ResourceDataGroups: []ResourceDataGroup{
+		{ResourceDatas: []ResourceData{{Path: "/3D/a.model"}}},
+		{ResourceDatas: []ResourceData{{Path: "/3D/b.model"}, {Path: "/3D/Textures/c.png"}}},
+	}}
+	tests := []struct {
+		name      string
+		path      string
+		wantGroup int
+		want      *ResourceData
+		wantOk    bool
+	}{
+		{"first", "/3D/a.model", 0, &ks.ResourceDataGroups[0].ResourceDatas[0], true},
+		{"caseInsensitive", "/3d/textures/C.png", 1, &ks.ResourceDataGroups[1].ResourceDatas[1], true},
+		{"notFound", "/3D/3dmodel.model", 0, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotGroup, got, gotOk := ks.FindResourceData(tt.path)
+			if gotGroup != tt.wantGroup || got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("KeyStore.FindResourceData() = %v, %v, %v, want %v, %v, %v", gotGroup, got, gotOk, tt.wantGroup, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
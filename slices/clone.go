@@ -0,0 +1,34 @@
+package slices
+
+import "github.com/qmuntal/go3mf"
+
+// Clone returns a deep copy of s.
+func (s *SliceStack) Clone() interface{} {
+	c := *s
+	if s.Slices != nil {
+		c.Slices = make([]*Slice, len(s.Slices))
+		for i, sl := range s.Slices {
+			c.Slices[i] = sl.clone()
+		}
+	}
+	c.Refs = append([]SliceRef(nil), s.Refs...)
+	return &c
+}
+
+func (s *Slice) clone() *Slice {
+	c := *s
+	c.Vertices = append([]go3mf.Point2D(nil), s.Vertices...)
+	if s.Polygons != nil {
+		c.Polygons = make([]Polygon, len(s.Polygons))
+		for i, p := range s.Polygons {
+			c.Polygons[i] = Polygon{StartV: p.StartV, Segments: append([]Segment(nil), p.Segments...)}
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of s.
+func (s *ObjectAttr) Clone() interface{} {
+	c := *s
+	return &c
+}
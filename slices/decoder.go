@@ -10,8 +10,8 @@ import (
 )
 
 func (Spec) CreateElementDecoder(parent interface{}, name string) spec.ElementDecoder {
-	if name == attrSliceStack {
-		return &sliceStackDecoder{resources: parent.(*go3mf.Resources)}
+	if resources, ok := parent.(*go3mf.Resources); ok && name == attrSliceStack {
+		return &sliceStackDecoder{resources: resources}
 	}
 	return nil
 }
@@ -0,0 +1,28 @@
+package slices
+
+// RemapIDs replaces the resource ID with the one returned by fn.
+// The IDs of the slice stacks referenced by Refs belong to other parts
+// and are rewritten by RemapPartIDs.
+func (s *SliceStack) RemapIDs(fn func(uint32) uint32) {
+	s.ID = fn(s.ID)
+}
+
+// RemapPartIDs replaces the IDs of the slice stacks referenced by Refs
+// with the ones returned by fn.
+func (s *SliceStack) RemapPartIDs(fn func(string, uint32) uint32) {
+	for i := range s.Refs {
+		s.Refs[i].SliceStackID = fn(s.Refs[i].Path, s.Refs[i].SliceStackID)
+	}
+}
+
+// RemapPaths replaces the paths of Refs with the ones returned by fn.
+func (s *SliceStack) RemapPaths(fn func(string) string) {
+	for i := range s.Refs {
+		s.Refs[i].Path = fn(s.Refs[i].Path)
+	}
+}
+
+// RemapIDs replaces the slice stack ID with the one returned by fn.
+func (s *ObjectAttr) RemapIDs(fn func(uint32) uint32) {
+	s.SliceStackID = fn(s.SliceStackID)
+}
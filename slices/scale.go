@@ -0,0 +1,14 @@
+package slices
+
+import "github.com/qmuntal/go3mf"
+
+// Scale multiplies the slice heights and vertices by factor.
+func (s *SliceStack) Scale(factor float32) {
+	s.BottomZ *= factor
+	for _, sl := range s.Slices {
+		sl.TopZ *= factor
+		for i, v := range sl.Vertices {
+			sl.Vertices[i] = go3mf.Point2D{v[0] * factor, v[1] * factor}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/go-test/deep"
 	"github.com/qmuntal/go3mf"
 	"github.com/qmuntal/go3mf/spec"
 )
@@ -69,3 +70,152 @@ func Test_newMeshResolution(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"slicestack", func() spec.Cloner {
+			return &SliceStack{ID: 1, BottomZ: 1, Slices: []*Slice{{TopZ: 2, Vertices: []go3mf.Point2D{{0, 0}, {1, 0}}, Polygons: []Polygon{{StartV: 0, Segments: []Segment{{V2: 1}}}}}}}
+		}, func(c interface{}) {
+			s := c.(*SliceStack)
+			s.Slices[0].TopZ = 3
+			s.Slices[0].Vertices[0] = go3mf.Point2D{2, 2}
+			s.Slices[0].Polygons[0].Segments[0].V2 = 0
+		}},
+		{"slicerefs", func() spec.Cloner {
+			return &SliceStack{ID: 2, Refs: []SliceRef{{SliceStackID: 1, Path: "/3D/other.model"}}}
+		}, func(c interface{}) { c.(*SliceStack).Refs[0].Path = "/3D/new.model" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	partIDs := func(p string, id uint32) uint32 {
+		if p == "/a.model" {
+			return id + 20
+		}
+		return id
+	}
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"slicestack", &SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 2, Path: "/a.model"}}}, &SliceStack{ID: 11, Refs: []SliceRef{{SliceStackID: 22, Path: "/a.model_1"}}}},
+		{"objectattr", &ObjectAttr{SliceStackID: 1}, &ObjectAttr{SliceStackID: 11}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PartIDRemapper); ok {
+				r.RemapPartIDs(partIDs)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name string
+		v    spec.Scaler
+		want interface{}
+	}{
+		{"slicestack", &SliceStack{BottomZ: 1, Slices: []*Slice{{TopZ: 2, Vertices: []go3mf.Point2D{{1, 2}}}}}, &SliceStack{BottomZ: 2, Slices: []*Slice{{TopZ: 4, Vertices: []go3mf.Point2D{{2, 4}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.v.Scale(2)
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T.Scale() = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompact_SliceRefs(t *testing.T) {
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{&SliceStack{ID: 3, Refs: []SliceRef{{SliceStackID: 7, Path: "/3D/slices.model"}}}},
+			Objects: []*go3mf.Object{
+				{ID: 4, Mesh: new(go3mf.Mesh), AnyAttr: go3mf.AnyAttr{&ObjectAttr{SliceStackID: 3}}},
+			},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 4}}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/slices.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 5, Slices: []*Slice{{TopZ: 1}}},
+				&SliceStack{ID: 7, Slices: []*Slice{{TopZ: 2}}},
+			}}},
+		},
+	}
+	m.Compact()
+	want := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 2, Path: "/3D/slices.model"}}}},
+			Objects: []*go3mf.Object{
+				{ID: 2, Mesh: new(go3mf.Mesh), AnyAttr: go3mf.AnyAttr{&ObjectAttr{SliceStackID: 1}}},
+			},
+		},
+		Build: go3mf.Build{Items: []*go3mf.Item{{ObjectID: 2}}},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/slices.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 1, Slices: []*Slice{{TopZ: 1}}},
+				&SliceStack{ID: 2, Slices: []*Slice{{TopZ: 2}}},
+			}}},
+		},
+	}
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Model.Compact() = %v", diff)
+	}
+}
+
+func TestModel_InlineChilds_SliceRefs(t *testing.T) {
+	m := &go3mf.Model{
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 1, Path: "/3D/slices.model"}}}},
+			Objects: []*go3mf.Object{
+				{ID: 2, Mesh: new(go3mf.Mesh), AnyAttr: go3mf.AnyAttr{&ObjectAttr{SliceStackID: 1}}},
+			},
+		},
+		Childs: map[string]*go3mf.ChildModel{
+			"/3D/slices.model": {Resources: go3mf.Resources{Assets: []go3mf.Asset{
+				&SliceStack{ID: 1, Slices: []*Slice{{TopZ: 1}}},
+			}}},
+		},
+	}
+	if err := m.InlineChilds(); err != nil {
+		t.Fatalf("Model.InlineChilds() error = %v", err)
+	}
+	want := []go3mf.Asset{
+		&SliceStack{ID: 1, Refs: []SliceRef{{SliceStackID: 3}}},
+		&SliceStack{ID: 3, Slices: []*Slice{{TopZ: 1}}},
+	}
+	if diff := deep.Equal(m.Resources.Assets, want); diff != nil {
+		t.Errorf("Model.InlineChilds() = %v", diff)
+	}
+}
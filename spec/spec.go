@@ -50,6 +50,56 @@ type MarshalerAttr interface {
 	Marshal3MFAttr(Encoder) ([]xml.Attr, error)
 }
 
+// Cloner is the interface implemented by extension values
+// that can return a deep copy of themselves.
+//
+// Extension values that do not implement Cloner are shared
+// between a model and its clones.
+type Cloner interface {
+	Clone() interface{}
+}
+
+// IDRemapper is the interface implemented by extension values
+// that reference resources by ID, so the references can be
+// rewritten when resources are renumbered.
+//
+// RemapIDs must replace every ID with the one returned by fn.
+// Assets must also replace their own ID. It can be called with a function
+// that returns its argument to find the referenced resources.
+type IDRemapper interface {
+	RemapIDs(fn func(uint32) uint32)
+}
+
+// PathRemapper is the interface implemented by extension values
+// that reference package parts by path, so the references can be
+// rewritten when parts are renamed.
+//
+// RemapPaths must replace every path with the one returned by fn.
+// It can be called with a function that returns its argument
+// to find the referenced parts.
+type PathRemapper interface {
+	RemapPaths(fn func(string) string)
+}
+
+// PartIDRemapper is the interface implemented by extension values
+// that reference resources defined in other model parts by path and ID,
+// so the references can be rewritten when the resources of a part are renumbered.
+//
+// RemapPartIDs must replace every such ID with the one returned by fn,
+// which receives the referenced path as stored in the value. It is called
+// before RemapPaths, so the paths have not been rewritten yet.
+type PartIDRemapper interface {
+	RemapPartIDs(fn func(path string, id uint32) uint32)
+}
+
+// Scaler is the interface implemented by extension values
+// that store lengths, so they can be rescaled when the model units change.
+//
+// Scale must multiply every length by factor.
+type Scaler interface {
+	Scale(factor float32)
+}
+
 type ErrorWrapper interface {
 	Wrap(error) error
 }
@@ -0,0 +1,186 @@
+package go3mf
+
+import "sort"
+
+// TopologyIssueKind defines the kind of a mesh topology issue.
+type TopologyIssueKind uint8
+
+// Supported topology issue kinds.
+const (
+	IssueDegenerateTriangle TopologyIssueKind = iota
+	IssueDuplicateTriangle
+	IssueBoundaryEdge
+	IssueNonManifoldEdge
+	IssueInconsistentOrientation
+	IssueIsolatedVertex
+)
+
+func (k TopologyIssueKind) String() string {
+	return map[TopologyIssueKind]string{
+		IssueDegenerateTriangle:      "degenerate triangle",
+		IssueDuplicateTriangle:       "duplicate triangle",
+		IssueBoundaryEdge:            "boundary edge",
+		IssueNonManifoldEdge:         "non-manifold edge",
+		IssueInconsistentOrientation: "inconsistent orientation",
+		IssueIsolatedVertex:          "isolated vertex",
+	}[k]
+}
+
+// TopologyIssue defines a mesh defect and the triangles and vertices involved.
+//
+// Degenerate and duplicate triangles contain the triangle indices and their vertices.
+// Edge issues contain the triangles sharing the edge and the two edge vertices.
+// Isolated vertices only contain the vertex index.
+type TopologyIssue struct {
+	Kind      TopologyIssueKind
+	Triangles []uint32
+	Vertices  []uint32
+}
+
+// AnalyzeTopology returns every topology issue detected in the mesh, sorted by kind.
+// A closed, manifold and consistently oriented mesh has no issues.
+//
+// A triangle is degenerate if it repeats a vertex or its area is zero
+// and duplicated if another triangle uses the same vertices, regardless of their order.
+// An edge is a boundary edge if it is only used by one triangle, non-manifold if it is
+// used by more than two triangles and inconsistently oriented if it is used by two triangles
+// that traverse it in the same direction.
+func (m *Mesh) AnalyzeTopology() []TopologyIssue {
+	var issues []TopologyIssue
+	issues = append(issues, m.triangleIssues()...)
+	issues = append(issues, m.edgeIssues()...)
+	issues = append(issues, m.isolatedVertices()...)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Kind < issues[j].Kind })
+	return issues
+}
+
+func (m *Mesh) triangleIssues() []TopologyIssue {
+	var issues []TopologyIssue
+	firsts := make(map[[3]uint32]int)
+	for i, t := range m.Triangles {
+		v1, v2, v3 := t.Indices()
+		if v1 == v2 || v2 == v3 || v1 == v3 || m.isZeroArea(v1, v2, v3) {
+			issues = append(issues, TopologyIssue{Kind: IssueDegenerateTriangle, Triangles: []uint32{uint32(i)}, Vertices: []uint32{v1, v2, v3}})
+			continue
+		}
+		key := sortedIndices(v1, v2, v3)
+		if idx, ok := firsts[key]; ok {
+			issues[idx].Triangles = append(issues[idx].Triangles, uint32(i))
+		} else {
+			issues = append(issues, TopologyIssue{Kind: IssueDuplicateTriangle, Triangles: []uint32{uint32(i)}, Vertices: []uint32{v1, v2, v3}})
+			firsts[key] = len(issues) - 1
+		}
+	}
+	// Remove the triangles that are not duplicated.
+	kept := issues[:0]
+	for _, issue := range issues {
+		if issue.Kind != IssueDuplicateTriangle || len(issue.Triangles) > 1 {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+func (m *Mesh) isZeroArea(v1, v2, v3 uint32) bool {
+	n := uint32(len(m.Vertices))
+	if v1 >= n || v2 >= n || v3 >= n {
+		return false
+	}
+	a, b, c := toFloat64(m.Vertices[v1]), toFloat64(m.Vertices[v2]), toFloat64(m.Vertices[v3])
+	ab, ac := sub64(b, a), sub64(c, a)
+	scale := dot64(ab, ab)
+	if l := dot64(ac, ac); l > scale {
+		scale = l
+	}
+	return norm64(cross64(ab, ac)) <= 1e-9*scale
+}
+
+func sortedIndices(v1, v2, v3 uint32) [3]uint32 {
+	if v1 > v2 {
+		v1, v2 = v2, v1
+	}
+	if v2 > v3 {
+		v2, v3 = v3, v2
+	}
+	if v1 > v2 {
+		v1, v2 = v2, v1
+	}
+	return [3]uint32{v1, v2, v3}
+}
+
+// meshEdge contains the triangles that use an edge and the number
+// of times the edge is traversed in each direction.
+type meshEdge struct {
+	v1, v2             uint32
+	triangles          []uint32
+	positive, negative uint32
+}
+
+// edges returns the edges of the mesh in order of appearance,
+// ignoring the ones that join a vertex with itself.
+func (m *Mesh) edges() []meshEdge {
+	var edges []meshEdge
+	pairMatching := make(pairMatch)
+	for i, face := range m.Triangles {
+		for j := 0; j < 3; j++ {
+			n1, n2 := face[j].ToUint32(), face[(j+1)%3].ToUint32()
+			if n1 == n2 {
+				continue
+			}
+			idx, ok := pairMatching.CheckMatch(n1, n2)
+			if !ok {
+				idx = uint32(len(edges))
+				pairMatching.AddMatch(n1, n2, idx)
+				e := newPairEntry(n1, n2)
+				edges = append(edges, meshEdge{v1: e.a, v2: e.b})
+			}
+			e := &edges[idx]
+			if len(e.triangles) == 0 || e.triangles[len(e.triangles)-1] != uint32(i) {
+				e.triangles = append(e.triangles, uint32(i))
+			}
+			if n1 <= n2 {
+				e.positive++
+			} else {
+				e.negative++
+			}
+		}
+	}
+	return edges
+}
+
+func (m *Mesh) edgeIssues() []TopologyIssue {
+	var issues []TopologyIssue
+	for _, e := range m.edges() {
+		var kind TopologyIssueKind
+		switch count := e.positive + e.negative; {
+		case count == 1:
+			kind = IssueBoundaryEdge
+		case count > 2:
+			kind = IssueNonManifoldEdge
+		case e.positive != 1:
+			kind = IssueInconsistentOrientation
+		default:
+			continue
+		}
+		issues = append(issues, TopologyIssue{Kind: kind, Triangles: e.triangles, Vertices: []uint32{e.v1, e.v2}})
+	}
+	return issues
+}
+
+func (m *Mesh) isolatedVertices() []TopologyIssue {
+	used := make([]bool, len(m.Vertices))
+	for _, t := range m.Triangles {
+		for j := 0; j < 3; j++ {
+			if v := t[j].ToUint32(); v < uint32(len(used)) {
+				used[v] = true
+			}
+		}
+	}
+	var issues []TopologyIssue
+	for i, ok := range used {
+		if !ok {
+			issues = append(issues, TopologyIssue{Kind: IssueIsolatedVertex, Vertices: []uint32{uint32(i)}})
+		}
+	}
+	return issues
+}
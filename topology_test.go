@@ -0,0 +1,69 @@
+package go3mf
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestMesh_AnalyzeTopology(t *testing.T) {
+	open := newCube(Point3D{}, 1)
+	open.Triangles = open.Triangles[1:]
+	flipped := newCube(Point3D{}, 1)
+	flipped.Triangles[0] = NewTriangle(0, 1, 2)
+	isolated := newCube(Point3D{}, 1)
+	isolated.Vertices = append(isolated.Vertices, Point3D{5, 5, 5})
+	degenerate := newCube(Point3D{}, 1)
+	degenerate.Vertices = append(degenerate.Vertices, Point3D{0.5, 0, 0})
+	degenerate.Triangles = append(degenerate.Triangles, NewTriangle(0, 8, 1), NewTriangle(3, 3, 1))
+	duplicate := newCube(Point3D{}, 1)
+	duplicate.Triangles = append(duplicate.Triangles, NewTriangle(2, 1, 0))
+	tests := []struct {
+		name string
+		m    *Mesh
+		want []TopologyIssue
+	}{
+		{"empty", new(Mesh), nil},
+		{"closed", newCube(Point3D{}, 1), nil},
+		{"open", open, []TopologyIssue{
+			{Kind: IssueBoundaryEdge, Triangles: []uint32{0}, Vertices: []uint32{1, 2}},
+			{Kind: IssueBoundaryEdge, Triangles: []uint32{3}, Vertices: []uint32{0, 1}},
+			{Kind: IssueBoundaryEdge, Triangles: []uint32{8}, Vertices: []uint32{0, 2}},
+		}},
+		{"flipped", flipped, []TopologyIssue{
+			{Kind: IssueInconsistentOrientation, Triangles: []uint32{0, 4}, Vertices: []uint32{0, 1}},
+			{Kind: IssueInconsistentOrientation, Triangles: []uint32{0, 1}, Vertices: []uint32{1, 2}},
+			{Kind: IssueInconsistentOrientation, Triangles: []uint32{0, 9}, Vertices: []uint32{0, 2}},
+		}},
+		{"isolated", isolated, []TopologyIssue{
+			{Kind: IssueIsolatedVertex, Vertices: []uint32{8}},
+		}},
+		{"degenerate", degenerate, []TopologyIssue{
+			{Kind: IssueDegenerateTriangle, Triangles: []uint32{12}, Vertices: []uint32{0, 8, 1}},
+			{Kind: IssueDegenerateTriangle, Triangles: []uint32{13}, Vertices: []uint32{3, 3, 1}},
+			{Kind: IssueBoundaryEdge, Triangles: []uint32{12}, Vertices: []uint32{0, 8}},
+			{Kind: IssueBoundaryEdge, Triangles: []uint32{12}, Vertices: []uint32{1, 8}},
+			{Kind: IssueNonManifoldEdge, Triangles: []uint32{0, 4, 12}, Vertices: []uint32{0, 1}},
+			{Kind: IssueNonManifoldEdge, Triangles: []uint32{1, 10, 13}, Vertices: []uint32{1, 3}},
+		}},
+		{"duplicate", duplicate, []TopologyIssue{
+			{Kind: IssueDuplicateTriangle, Triangles: []uint32{0, 12}, Vertices: []uint32{0, 2, 1}},
+			{Kind: IssueNonManifoldEdge, Triangles: []uint32{0, 9, 12}, Vertices: []uint32{0, 2}},
+			{Kind: IssueNonManifoldEdge, Triangles: []uint32{0, 1, 12}, Vertices: []uint32{1, 2}},
+			{Kind: IssueNonManifoldEdge, Triangles: []uint32{0, 4, 12}, Vertices: []uint32{0, 1}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.m.AnalyzeTopology(), tt.want); diff != nil {
+				t.Errorf("Mesh.AnalyzeTopology() = %v", diff)
+			}
+		})
+	}
+}
+
+func TestTopologyIssueKind_String(t *testing.T) {
+	if got := IssueNonManifoldEdge.String(); got != "non-manifold edge" {
+		t.Errorf("TopologyIssueKind.String() = %v", got)
+	}
+}
@@ -0,0 +1,121 @@
+package go3mf
+
+import "github.com/qmuntal/go3mf/spec"
+
+// unitsPerMeter contains the number of units in a meter.
+var unitsPerMeter = map[Units]float64{
+	UnitMicrometer: 1e6,
+	UnitMillimeter: 1e3,
+	UnitCentimeter: 1e2,
+	UnitInch:       1e3 / 25.4,
+	UnitFoot:       1e3 / 304.8,
+	UnitMeter:      1,
+}
+
+// Factor returns the factor a length in u units
+// has to be multiplied by to express it in to units.
+func (u Units) Factor(to Units) float32 {
+	from, ok1 := unitsPerMeter[u]
+	dst, ok2 := unitsPerMeter[to]
+	if !ok1 || !ok2 || u == to {
+		return 1
+	}
+	return float32(dst / from)
+}
+
+// ConvertUnits rescales every length of the model from the current units
+// to the target ones and sets them as the model units.
+//
+// The vertices, the translation of the item and component transforms
+// and the vertices provided by mesh sources are rescaled.
+// Extension values take part through spec.Scaler.
+func (m *Model) ConvertUnits(to Units) {
+	factor := m.Units.Factor(to)
+	m.Units = to
+	if factor == 1 {
+		return
+	}
+	s := scaler(factor)
+	s.resources(&m.Resources)
+	for _, child := range m.Childs {
+		s.resources(&child.Resources)
+		s.any(child.Any)
+	}
+	s.anyAttr(m.Build.AnyAttr)
+	for _, item := range m.Build.Items {
+		s.transform(&item.Transform)
+		s.anyAttr(item.AnyAttr)
+	}
+	s.any(m.Any)
+	s.anyAttr(m.AnyAttr)
+}
+
+type scaler float32
+
+func (s scaler) resources(rs *Resources) {
+	s.anyAttr(rs.AnyAttr)
+	for _, a := range rs.Assets {
+		s.extension(a)
+	}
+	for _, o := range rs.Objects {
+		s.anyAttr(o.AnyAttr)
+		s.any(o.Any)
+		if o.Mesh != nil {
+			s.mesh(o.Mesh)
+		}
+		for _, c := range o.Components {
+			s.transform(&c.Transform)
+			s.anyAttr(c.AnyAttr)
+		}
+	}
+}
+
+func (s scaler) mesh(mesh *Mesh) {
+	for i, v := range mesh.Vertices {
+		mesh.Vertices[i] = Point3D{v[0] * float32(s), v[1] * float32(s), v[2] * float32(s)}
+	}
+	if mesh.Source != nil {
+		mesh.Source = &scaledSource{MeshSource: mesh.Source, factor: float32(s)}
+	}
+	s.anyAttr(mesh.AnyAttr)
+	s.any(mesh.Any)
+}
+
+// transform scales the translation, so the transform can be applied
+// to the scaled vertices. An undefined transform is kept as is.
+func (s scaler) transform(t *Matrix) {
+	if *t == (Matrix{}) {
+		return
+	}
+	t[12] *= float32(s)
+	t[13] *= float32(s)
+	t[14] *= float32(s)
+}
+
+func (s scaler) anyAttr(e AnyAttr) {
+	for _, a := range e {
+		s.extension(a)
+	}
+}
+
+func (s scaler) any(e Any) {
+	for _, a := range e {
+		s.extension(a)
+	}
+}
+
+func (s scaler) extension(v interface{}) {
+	if sc, ok := v.(spec.Scaler); ok {
+		sc.Scale(float32(s))
+	}
+}
+
+type scaledSource struct {
+	MeshSource
+	factor float32
+}
+
+func (s *scaledSource) NextVertex() (Point3D, error) {
+	v, err := s.MeshSource.NextVertex()
+	return Point3D{v[0] * s.factor, v[1] * s.factor, v[2] * s.factor}, err
+}
@@ -0,0 +1,91 @@
+package go3mf
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+type fakeLength struct {
+	fakeAsset
+	Length float32
+}
+
+func (f *fakeLength) Scale(factor float32) { f.Length *= factor }
+
+func TestUnits_Factor(t *testing.T) {
+	tests := []struct {
+		from, to Units
+		want     float32
+	}{
+		{UnitMillimeter, UnitMillimeter, 1},
+		{UnitInch, UnitMillimeter, 25.4},
+		{UnitMillimeter, UnitInch, 1 / 25.4},
+		{UnitFoot, UnitInch, 12},
+		{UnitMeter, UnitCentimeter, 100},
+		{UnitMicrometer, UnitMillimeter, 0.001},
+		{Units(100), UnitMillimeter, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from.String()+"-"+tt.to.String(), func(t *testing.T) {
+			if got := tt.from.Factor(tt.to); deep.Equal(got, tt.want) != nil {
+				t.Errorf("Units.Factor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_ConvertUnits(t *testing.T) {
+	m := &Model{
+		Units: UnitCentimeter,
+		Resources: Resources{
+			Assets: []Asset{&fakeLength{fakeAsset: fakeAsset{ID: 1}, Length: 2}},
+			Objects: []*Object{
+				{ID: 2, Mesh: &Mesh{Vertices: []Point3D{{1, 2, 3}}, Any: Any{&fakeLength{Length: 1}}}},
+				{ID: 3, Components: []*Component{
+					{ObjectID: 2, Transform: Matrix{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 2, 3, 1}},
+					{ObjectID: 2},
+				}},
+				{ID: 4, Mesh: &Mesh{Source: &stripSource{n: 2}}},
+			},
+		},
+		Build: Build{Items: []*Item{{ObjectID: 3, Transform: Identity().Translate(0, 0, 1)}}},
+		Childs: map[string]*ChildModel{
+			"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{0, 0, 1}}}}}}},
+		},
+	}
+	want := &Model{
+		Units: UnitMillimeter,
+		Resources: Resources{
+			Assets: []Asset{&fakeLength{fakeAsset: fakeAsset{ID: 1}, Length: 20}},
+			Objects: []*Object{
+				{ID: 2, Mesh: &Mesh{Vertices: []Point3D{{10, 20, 30}}, Any: Any{&fakeLength{Length: 10}}}},
+				{ID: 3, Components: []*Component{
+					{ObjectID: 2, Transform: Matrix{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 10, 20, 30, 1}},
+					{ObjectID: 2},
+				}},
+				{ID: 4, Mesh: m.Resources.Objects[2].Mesh},
+			},
+		},
+		Build: Build{Items: []*Item{{ObjectID: 3, Transform: Identity().Translate(0, 0, 10)}}},
+		Childs: map[string]*ChildModel{
+			"/3D/other.model": {Resources: Resources{Objects: []*Object{{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{0, 0, 10}}}}}}},
+		},
+	}
+	m.ConvertUnits(UnitMillimeter)
+	if diff := deep.Equal(m, want); diff != nil {
+		t.Errorf("Model.ConvertUnits() = %v", diff)
+	}
+	var got []Point3D
+	for {
+		v, err := m.Resources.Objects[2].Mesh.Source.NextVertex()
+		if err == io.EOF {
+			break
+		}
+		got = append(got, v)
+	}
+	if diff := deep.Equal(got, []Point3D{{0, 0, 0}, {0, 10, 0}, {10, 0, 0}, {10, 10, 0}}); diff != nil {
+		t.Errorf("Model.ConvertUnits() source vertices = %v", diff)
+	}
+}
@@ -205,7 +205,7 @@ func (r *Object) Validate(m *Model, path string) error {
 	if r.PIndex != 0 && r.PID == 0 {
 		errs = errors.Append(errs, errors.NewMissingFieldError(attrPID))
 	}
-	if (r.Mesh != nil && len(r.Components) > 0) || (r.Mesh == nil && len(r.Components) == 0) {
+	if (r.Mesh != nil && len(r.Components) > 0) || (r.Mesh == nil && len(r.Components) == 0 && len(r.Any) == 0) {
 		errs = errors.Append(errs, errors.ErrInvalidObject)
 	}
 	if r.Mesh != nil {
@@ -281,6 +281,31 @@ func (r *Object) validateMesh(m *Model, path string) error {
 			}
 		}
 	}
+	errs = errors.Append(errs, r.Mesh.validateTriangleSets())
+	return errs
+}
+
+func (m *Mesh) validateTriangleSets() error {
+	var errs error
+	identifiers := make(map[string]struct{}, len(m.TriangleSets))
+	for i, set := range m.TriangleSets {
+		if set.Name == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrName), set, i))
+		}
+		if set.Identifier == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrIdentifier), set, i))
+		} else if _, ok := identifiers[set.Identifier]; ok {
+			errs = errors.Append(errs, errors.WrapIndex(errors.ErrDuplicatedTriangleSet, set, i))
+		} else {
+			identifiers[set.Identifier] = struct{}{}
+		}
+		for _, ref := range set.Refs {
+			if int(ref) >= len(m.Triangles) {
+				errs = errors.Append(errs, errors.WrapIndex(errors.ErrIndexOutOfBounds, set, i))
+				break
+			}
+		}
+	}
 	return errs
 }
 
@@ -385,7 +410,7 @@ func (m *Model) ValidateCoherency() error {
 	for path, c := range m.Childs {
 		wg.Add(len(c.Resources.Objects))
 		for i := range c.Resources.Objects {
-			go func(i int) {
+			go func(path string, c *ChildModel, i int) {
 				defer wg.Done()
 				r := c.Resources.Objects[i]
 				if isSolidObject(r) {
@@ -396,7 +421,7 @@ func (m *Model) ValidateCoherency() error {
 						mu.Unlock()
 					}
 				}
-			}(i)
+			}(path, c, i)
 		}
 	}
 	wg.Wait()
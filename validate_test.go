@@ -124,6 +124,20 @@ func TestValidate(t *testing.T) {
 			fmt.Sprintf("Resources@Object#5@Mesh@Triangle#1: %v", errors.ErrIndexOutOfBounds),
 			fmt.Sprintf("Resources@Object#5@Mesh@Triangle#3: %v", errors.ErrMissingResource),
 		}},
+		{"triangle sets", &Model{Resources: Resources{Objects: []*Object{
+			{ID: 1, Mesh: &Mesh{Vertices: []Point3D{{}, {}, {}, {}}, Triangles: []Triangle{
+				NewTriangle(0, 1, 2), NewTriangle(0, 3, 1), NewTriangle(0, 2, 3), NewTriangle(1, 3, 2),
+			}, TriangleSets: []TriangleSet{
+				{Name: "a", Identifier: "a", Refs: []uint32{0, 1, 2, 3}},
+				{},
+				{Name: "b", Identifier: "a", Refs: []uint32{0, 4, 5}},
+			}}},
+		}}}, []string{
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#1: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#1: %v", &errors.MissingFieldError{Name: attrIdentifier}),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#2: %v", errors.ErrDuplicatedTriangleSet),
+			fmt.Sprintf("Resources@Object#0@Mesh@TriangleSet#2: %v", errors.ErrIndexOutOfBounds),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -150,6 +164,24 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestObject_Validate_Any(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       *Object
+		wantErr bool
+	}{
+		{"empty", &Object{ID: 1}, true},
+		{"any", &Object{ID: 1, Any: Any{&fakeAsset{ID: 2}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(new(Model), ""); (err != nil) != tt.wantErr {
+				t.Errorf("Object.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestObject_ValidateMesh(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -210,6 +242,13 @@ func TestModel_ValidateCoherency(t *testing.T) {
 			fmt.Sprintf("/other.model@Resources@Object#0@Mesh: %v", errors.ErrMeshConsistency),
 			fmt.Sprintf("Resources@Object#0@Mesh: %v", errors.ErrMeshConsistency),
 		}},
+		{"childs", &Model{Childs: map[string]*ChildModel{
+			"/a.model": {Resources: Resources{Objects: []*Object{{Mesh: invalidMesh}}}},
+			"/b.model": {Resources: Resources{Objects: []*Object{{Mesh: validMesh}, {Mesh: validMesh}}}},
+			"/c.model": {Resources: Resources{Objects: []*Object{{Mesh: validMesh}}}},
+		}}, []string{
+			fmt.Sprintf("/a.model@Resources@Object#0@Mesh: %v", errors.ErrMeshConsistency),
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
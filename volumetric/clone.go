@@ -0,0 +1,19 @@
+package volumetric
+
+// Clone returns a deep copy of r.
+func (r *ImageStack) Clone() interface{} {
+	c := *r
+	c.Sheets = append([]ImageSheet(nil), r.Sheets...)
+	return &c
+}
+
+// Clone returns a deep copy of v.
+func (v *VolumetricData) Clone() interface{} {
+	c := *v
+	if v.LevelSet != nil {
+		ls := *v.LevelSet
+		c.LevelSet = &ls
+	}
+	c.Properties = append([]PropertyChannel(nil), v.Properties...)
+	return &c
+}
@@ -0,0 +1,231 @@
+package volumetric
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf"
+	specerr "github.com/qmuntal/go3mf/errors"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+func (Spec) DecodeAttribute(interface{}, spec.Attr) error {
+	return nil
+}
+
+func (Spec) CreateElementDecoder(parent interface{}, name string) (child spec.ElementDecoder) {
+	switch parent := parent.(type) {
+	case *go3mf.Resources:
+		if name == attrImageStack {
+			child = &imageStackDecoder{resources: parent}
+		}
+	case *go3mf.Mesh:
+		if name == attrVolumetricData {
+			child = &volumetricDataDecoder{mesh: parent}
+		}
+	}
+	return
+}
+
+type imageStackDecoder struct {
+	baseDecoder
+	resources         *go3mf.Resources
+	resource          ImageStack
+	imageSheetDecoder imageSheetDecoder
+}
+
+func (d *imageStackDecoder) End() {
+	d.resources.Assets = append(d.resources.Assets, &d.resource)
+}
+
+func (d *imageStackDecoder) Wrap(err error) error {
+	return specerr.WrapIndex(err, &d.resource, len(d.resources.Assets))
+}
+
+func (d *imageStackDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace && name.Local == attrImageSheet {
+		child = &d.imageSheetDecoder
+	}
+	return
+}
+
+func (d *imageStackDecoder) Start(attrs []spec.Attr) error {
+	var errs error
+	d.imageSheetDecoder.resource = &d.resource
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		var field *uint32
+		switch a.Name.Local {
+		case attrID:
+			field = &d.resource.ID
+		case attrRowCount:
+			field = &d.resource.RowCount
+		case attrColumnCount:
+			field = &d.resource.ColumnCount
+		case attrSheetCount:
+			field = &d.resource.SheetCount
+		default:
+			continue
+		}
+		val, err := strconv.ParseUint(string(a.Value), 10, 32)
+		if err != nil {
+			errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+		}
+		*field = uint32(val)
+	}
+	if errs != nil {
+		return specerr.WrapIndex(errs, &d.resource, len(d.resources.Assets))
+	}
+	return nil
+}
+
+type imageSheetDecoder struct {
+	baseDecoder
+	resource *ImageStack
+}
+
+func (d *imageSheetDecoder) Start(attrs []spec.Attr) error {
+	var sheet ImageSheet
+	for _, a := range attrs {
+		if a.Name.Space == "" && a.Name.Local == attrPath {
+			sheet.Path = string(a.Value)
+			break
+		}
+	}
+	d.resource.Sheets = append(d.resource.Sheets, sheet)
+	return nil
+}
+
+type volumetricDataDecoder struct {
+	baseDecoder
+	mesh *go3mf.Mesh
+	data *VolumetricData
+}
+
+func (d *volumetricDataDecoder) Start(_ []spec.Attr) error {
+	d.data = new(VolumetricData)
+	d.mesh.Any = append(d.mesh.Any, d.data)
+	return nil
+}
+
+func (d *volumetricDataDecoder) Wrap(err error) error {
+	return specerr.Wrap(err, d.data)
+}
+
+func (d *volumetricDataDecoder) Child(name xml.Name) (child spec.ElementDecoder) {
+	if name.Space == Namespace {
+		if name.Local == attrLevelSet {
+			child = &levelSetDecoder{data: d.data}
+		} else if name.Local == attrProperty {
+			child = &propertyDecoder{data: d.data}
+		}
+	}
+	return
+}
+
+type levelSetDecoder struct {
+	baseDecoder
+	data *VolumetricData
+}
+
+func (d *levelSetDecoder) Start(attrs []spec.Attr) error {
+	var (
+		ls   LevelSet
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrImageStackID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			ls.ImageStackID = uint32(val)
+		case attrChannel:
+			var ok bool
+			if ls.Channel, ok = newChannel(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+		case attrTransform:
+			var ok bool
+			if ls.Transform, ok = spec.ParseMatrix(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrSolidThreshold, attrMinFeatureSize, attrFallbackValue:
+			val, err := strconv.ParseFloat(string(a.Value), 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, a.Name.Local == attrSolidThreshold))
+			}
+			switch a.Name.Local {
+			case attrSolidThreshold:
+				ls.SolidThreshold = float32(val)
+			case attrMinFeatureSize:
+				ls.MinFeatureSize = float32(val)
+			default:
+				ls.FallbackValue = float32(val)
+			}
+		case attrMeshBBoxOnly:
+			ls.MeshBBoxOnly, _ = strconv.ParseBool(string(a.Value))
+		}
+	}
+	d.data.LevelSet = &ls
+	if errs != nil {
+		return specerr.Wrap(errs, &ls)
+	}
+	return nil
+}
+
+type propertyDecoder struct {
+	baseDecoder
+	data *VolumetricData
+}
+
+func (d *propertyDecoder) Start(attrs []spec.Attr) error {
+	var (
+		p    PropertyChannel
+		errs error
+	)
+	for _, a := range attrs {
+		if a.Name.Space != "" {
+			continue
+		}
+		switch a.Name.Local {
+		case attrName:
+			p.Name = string(a.Value)
+		case attrImageStackID:
+			val, err := strconv.ParseUint(string(a.Value), 10, 32)
+			if err != nil {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+			p.ImageStackID = uint32(val)
+		case attrChannel:
+			var ok bool
+			if p.Channel, ok = newChannel(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, true))
+			}
+		case attrTransform:
+			var ok bool
+			if p.Transform, ok = spec.ParseMatrix(string(a.Value)); !ok {
+				errs = specerr.Append(errs, specerr.NewParseAttrError(a.Name.Local, false))
+			}
+		case attrRequired:
+			p.Required, _ = strconv.ParseBool(string(a.Value))
+		}
+	}
+	d.data.Properties = append(d.data.Properties, p)
+	if errs != nil {
+		return specerr.WrapIndex(errs, p, len(d.data.Properties)-1)
+	}
+	return nil
+}
+
+type baseDecoder struct {
+}
+
+func (d *baseDecoder) Start([]spec.Attr) error { return nil }
+func (d *baseDecoder) End()                    {}
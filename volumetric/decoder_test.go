@@ -0,0 +1,137 @@
+package volumetric
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestDecode(t *testing.T) {
+	stack := &ImageStack{ID: 1, RowCount: 128, ColumnCount: 256, SheetCount: 2, Sheets: []ImageSheet{
+		{Path: "/3D/Volume/sheet0.png"},
+		{Path: "/3D/Volume/sheet1.png"},
+	}}
+	data := &VolumetricData{
+		LevelSet: &LevelSet{
+			ImageStackID: 1, Channel: ChannelG, SolidThreshold: 0.5, MinFeatureSize: 0.1,
+			FallbackValue: -1, MeshBBoxOnly: true, Transform: go3mf.Identity().Translate(0.5, 0.5, 0.5),
+		},
+		Properties: []PropertyChannel{
+			{Name: "density", ImageStackID: 1, Channel: ChannelA, Required: true},
+			{Name: "temperature", ImageStackID: 1, Transform: go3mf.Identity().Translate(1, 0, 0)},
+		},
+	}
+	want := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{stack},
+			Objects: []*go3mf.Object{
+				{ID: 2, Name: "Volume", Mesh: &go3mf.Mesh{
+					Vertices:  []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}},
+					Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2)},
+					Any:       go3mf.Any{data},
+				}},
+			},
+		},
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:v="http://schemas.microsoft.com/3dmanufacturing/volumetric/2022/01">
+		<resources>
+			<v:imagestack id="1" rowcount="128" columncount="256" sheetcount="2">
+				<v:imagesheet path="/3D/Volume/sheet0.png"/>
+				<v:imagesheet path="/3D/Volume/sheet1.png"/>
+			</v:imagestack>
+			<object id="2" name="Volume">
+				<mesh>
+					<vertices>
+						<vertex x="0" y="0" z="0"/>
+						<vertex x="10" y="0" z="0"/>
+						<vertex x="0" y="10" z="0"/>
+					</vertices>
+					<triangles>
+						<triangle v1="0" v2="1" v3="2"/>
+					</triangles>
+					<v:volumetricdata>
+						<v:levelset imagestackid="1" channel="G" solidthreshold="0.5" minfeaturesize="0.1" fallbackvalue="-1" meshbboxonly="true" transform="1 0 0 0 1 0 0 0 1 0.5 0.5 0.5"/>
+						<v:property name="density" imagestackid="1" channel="A" required="true"/>
+						<v:property name="temperature" imagestackid="1" channel="R" transform="1 0 0 0 1 0 0 0 1 1 0 0"/>
+					</v:volumetricdata>
+				</mesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		if err := go3mf.UnmarshalModel([]byte(rootFile), got); err != nil {
+			t.Errorf("DecodeRawModel() unexpected error = %v", err)
+			return
+		}
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("DecodeRawModel() = %v", diff)
+			return
+		}
+	})
+}
+
+func TestDecode_warns(t *testing.T) {
+	want := []string{
+		fmt.Sprintf("Resources@ImageStack#0: %v", errors.NewParseAttrError("id", true)),
+		fmt.Sprintf("Resources@ImageStack#0: %v", errors.NewParseAttrError("sheetcount", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@VolumetricData@LevelSet: %v", errors.NewParseAttrError("imagestackid", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@VolumetricData@LevelSet: %v", errors.NewParseAttrError("channel", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@VolumetricData@LevelSet: %v", errors.NewParseAttrError("minfeaturesize", false)),
+		fmt.Sprintf("Resources@Object#0@Mesh@VolumetricData@PropertyChannel#0: %v", errors.NewParseAttrError("channel", true)),
+		fmt.Sprintf("Resources@Object#0@Mesh@VolumetricData@PropertyChannel#0: %v", errors.NewParseAttrError("transform", false)),
+	}
+	got := new(go3mf.Model)
+	got.Path = "/3D/3dmodel.model"
+	rootFile := `
+		<model xmlns="http://schemas.microsoft.com/3dmanufacturing/core/2015/02" xmlns:v="http://schemas.microsoft.com/3dmanufacturing/volumetric/2022/01">
+		<resources>
+			<v:imagestack id="a" rowcount="1" columncount="1" sheetcount="b">
+				<v:imagesheet path="/3D/Volume/sheet0.png"/>
+			</v:imagestack>
+			<object id="2" name="Volume">
+				<mesh>
+					<vertices>
+						<vertex x="0" y="0" z="0"/>
+						<vertex x="10" y="0" z="0"/>
+						<vertex x="0" y="10" z="0"/>
+					</vertices>
+					<triangles>
+						<triangle v1="0" v2="1" v3="2"/>
+					</triangles>
+					<v:volumetricdata>
+						<v:levelset imagestackid="a" channel="C" solidthreshold="0.5" minfeaturesize="b"/>
+						<v:property name="density" imagestackid="1" channel="C" transform="0 0"/>
+					</v:volumetricdata>
+				</mesh>
+			</object>
+		</resources>
+		<build/>
+		</model>
+		`
+
+	t.Run("base", func(t *testing.T) {
+		err := go3mf.UnmarshalModel([]byte(rootFile), got)
+		if err == nil {
+			t.Fatal("error expected")
+		}
+		var errs []string
+		for _, err := range err.(*errors.List).Errors {
+			errs = append(errs, err.Error())
+		}
+		if diff := deep.Equal(errs, want); diff != nil {
+			t.Errorf("UnmarshalModel_warn() = %v", diff)
+			return
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package volumetric
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/qmuntal/go3mf/spec"
+)
+
+// Marshal3MF encodes the resource.
+func (r *ImageStack) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrImageStack}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrID}, Value: strconv.FormatUint(uint64(r.ID), 10)},
+		{Name: xml.Name{Local: attrRowCount}, Value: strconv.FormatUint(uint64(r.RowCount), 10)},
+		{Name: xml.Name{Local: attrColumnCount}, Value: strconv.FormatUint(uint64(r.ColumnCount), 10)},
+		{Name: xml.Name{Local: attrSheetCount}, Value: strconv.FormatUint(uint64(r.SheetCount), 10)},
+	}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	for _, s := range r.Sheets {
+		x.AddRelationship(spec.Relationship{Path: s.Path, Type: RelTypeTexture3D})
+		x.EncodeToken(xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrImageSheet}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrPath}, Value: s.Path},
+		}})
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+// Marshal3MF encodes the resource.
+func (v *VolumetricData) Marshal3MF(x spec.Encoder) error {
+	xs := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrVolumetricData}}
+	x.EncodeToken(xs)
+	x.SetAutoClose(true)
+	if v.LevelSet != nil {
+		marshalLevelSet(x, v.LevelSet)
+	}
+	for _, p := range v.Properties {
+		xp := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrProperty}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: attrName}, Value: p.Name},
+			{Name: xml.Name{Local: attrImageStackID}, Value: strconv.FormatUint(uint64(p.ImageStackID), 10)},
+			{Name: xml.Name{Local: attrChannel}, Value: p.Channel.String()},
+		}}
+		if p.HasTransform() {
+			xp.Attr = append(xp.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: p.Transform.String()})
+		}
+		if p.Required {
+			xp.Attr = append(xp.Attr, xml.Attr{Name: xml.Name{Local: attrRequired}, Value: strconv.FormatBool(p.Required)})
+		}
+		x.EncodeToken(xp)
+	}
+	x.SetAutoClose(false)
+	x.EncodeToken(xs.End())
+	return nil
+}
+
+func marshalLevelSet(x spec.Encoder, ls *LevelSet) {
+	prec := x.FloatPresicion()
+	xl := xml.StartElement{Name: xml.Name{Space: Namespace, Local: attrLevelSet}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: attrImageStackID}, Value: strconv.FormatUint(uint64(ls.ImageStackID), 10)},
+		{Name: xml.Name{Local: attrChannel}, Value: ls.Channel.String()},
+		{Name: xml.Name{Local: attrSolidThreshold}, Value: strconv.FormatFloat(float64(ls.SolidThreshold), 'f', prec, 32)},
+	}}
+	if ls.HasTransform() {
+		xl.Attr = append(xl.Attr, xml.Attr{Name: xml.Name{Local: attrTransform}, Value: ls.Transform.String()})
+	}
+	if ls.MinFeatureSize != 0 {
+		xl.Attr = append(xl.Attr, xml.Attr{Name: xml.Name{Local: attrMinFeatureSize}, Value: strconv.FormatFloat(float64(ls.MinFeatureSize), 'f', prec, 32)})
+	}
+	if ls.FallbackValue != 0 {
+		xl.Attr = append(xl.Attr, xml.Attr{Name: xml.Name{Local: attrFallbackValue}, Value: strconv.FormatFloat(float64(ls.FallbackValue), 'f', prec, 32)})
+	}
+	if ls.MeshBBoxOnly {
+		xl.Attr = append(xl.Attr, xml.Attr{Name: xml.Name{Local: attrMeshBBoxOnly}, Value: strconv.FormatBool(ls.MeshBBoxOnly)})
+	}
+	x.EncodeToken(xl)
+}
@@ -0,0 +1,56 @@
+package volumetric
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+)
+
+func TestMarshalModel(t *testing.T) {
+	stack := &ImageStack{ID: 1, RowCount: 64, ColumnCount: 32, SheetCount: 2, Sheets: []ImageSheet{
+		{Path: "/3D/Volume/sheet0.png"},
+		{Path: "/3D/Volume/sheet1.png"},
+	}}
+	data := &VolumetricData{
+		LevelSet: &LevelSet{
+			ImageStackID: 1, Channel: ChannelB, SolidThreshold: 0.25, MinFeatureSize: 0.5,
+			FallbackValue: 1, MeshBBoxOnly: true, Transform: go3mf.Identity().Translate(2, 0, 1),
+		},
+		Properties: []PropertyChannel{
+			{Name: "density", ImageStackID: 1, Channel: ChannelA, Required: true, Transform: go3mf.Identity().Translate(0, 1, 0)},
+			{Name: "temperature", ImageStackID: 1},
+		},
+	}
+	m := &go3mf.Model{
+		Path:       "/3D/3dmodel.model",
+		Extensions: []go3mf.Extension{DefaultExtension},
+		Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{stack},
+			Objects: []*go3mf.Object{
+				{ID: 2, Name: "Volume", Mesh: &go3mf.Mesh{
+					Vertices:  []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}},
+					Triangles: []go3mf.Triangle{go3mf.NewTriangle(0, 1, 2)},
+					Any:       go3mf.Any{data},
+				}},
+			},
+		},
+	}
+
+	t.Run("base", func(t *testing.T) {
+		b, err := go3mf.MarshalModel(m)
+		if err != nil {
+			t.Errorf("volumetric.MarshalModel() error = %v", err)
+			return
+		}
+		newModel := new(go3mf.Model)
+		newModel.Path = m.Path
+		if err := go3mf.UnmarshalModel(b, newModel); err != nil {
+			t.Errorf("volumetric.MarshalModel() error decoding = %v, s = %s", err, string(b))
+			return
+		}
+		if diff := deep.Equal(m, newModel); diff != nil {
+			t.Errorf("volumetric.MarshalModel() = %v, s = %s", diff, string(b))
+		}
+	})
+}
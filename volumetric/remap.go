@@ -0,0 +1,23 @@
+package volumetric
+
+// RemapIDs replaces the resource ID with the one returned by fn.
+func (r *ImageStack) RemapIDs(fn func(uint32) uint32) {
+	r.ID = fn(r.ID)
+}
+
+// RemapPaths replaces the sheet paths with the ones returned by fn.
+func (r *ImageStack) RemapPaths(fn func(string) string) {
+	for i := range r.Sheets {
+		r.Sheets[i].Path = fn(r.Sheets[i].Path)
+	}
+}
+
+// RemapIDs replaces the image stack IDs with the ones returned by fn.
+func (v *VolumetricData) RemapIDs(fn func(uint32) uint32) {
+	if v.LevelSet != nil {
+		v.LevelSet.ImageStackID = fn(v.LevelSet.ImageStackID)
+	}
+	for i := range v.Properties {
+		v.Properties[i].ImageStackID = fn(v.Properties[i].ImageStackID)
+	}
+}
@@ -0,0 +1,28 @@
+package volumetric
+
+import "github.com/qmuntal/go3mf"
+
+// Scale multiplies the minimum feature size by factor and
+// updates the transforms so they keep mapping the scaled object
+// coordinates to the same image stack coordinates.
+func (v *VolumetricData) Scale(factor float32) {
+	if v.LevelSet != nil {
+		v.LevelSet.MinFeatureSize *= factor
+		v.LevelSet.Transform = scaleInput(v.LevelSet.Transform, factor)
+	}
+	for i := range v.Properties {
+		v.Properties[i].Transform = scaleInput(v.Properties[i].Transform, factor)
+	}
+}
+
+// scaleInput returns a transform that applied to a point scaled by factor
+// gives the same result as t applied to the original point.
+func scaleInput(t go3mf.Matrix, factor float32) go3mf.Matrix {
+	if t == (go3mf.Matrix{}) {
+		t = go3mf.Identity()
+	}
+	for _, i := range [...]int{0, 1, 2, 4, 5, 6, 8, 9, 10} {
+		t[i] /= factor
+	}
+	return t
+}
@@ -0,0 +1,105 @@
+package volumetric
+
+import (
+	"strings"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func (Spec) Validate(model interface{}, path string, element interface{}) error {
+	switch element := element.(type) {
+	case *go3mf.Object:
+		return validateObject(model.(*go3mf.Model), path, element)
+	case *ImageStack:
+		return validateImageStack(model.(*go3mf.Model), element)
+	}
+	return nil
+}
+
+func validateImageStack(m *go3mf.Model, r *ImageStack) (errs error) {
+	if r.ID == 0 {
+		errs = errors.Append(errs, errors.ErrMissingID)
+	}
+	if r.RowCount == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrRowCount))
+	}
+	if r.ColumnCount == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrColumnCount))
+	}
+	if r.SheetCount == 0 {
+		errs = errors.Append(errs, errors.NewMissingFieldError(attrSheetCount))
+	}
+	if int(r.SheetCount) != len(r.Sheets) {
+		errs = errors.Append(errs, ErrSheetCount)
+	}
+	for i, s := range r.Sheets {
+		if s.Path == "" {
+			errs = errors.Append(errs, errors.WrapIndex(errors.NewMissingFieldError(attrPath), s, i))
+		} else if !hasAttachment(m, s.Path) {
+			errs = errors.Append(errs, errors.WrapIndex(ErrMissingSheetPart, s, i))
+		}
+	}
+	return
+}
+
+func hasAttachment(m *go3mf.Model, path string) bool {
+	for _, a := range m.Attachments {
+		if strings.EqualFold(a.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateObject(m *go3mf.Model, path string, obj *go3mf.Object) error {
+	if obj.Mesh == nil {
+		return nil
+	}
+	data := GetVolumetricData(obj.Mesh)
+	if data == nil {
+		return nil
+	}
+	var errs, dErrs error
+	if obj.Type != go3mf.ObjectTypeModel && obj.Type != go3mf.ObjectTypeSolidSupport {
+		errs = errors.Append(errs, ErrVolumetricObjType)
+	}
+	if data.LevelSet != nil {
+		var lErrs error
+		if data.LevelSet.ImageStackID == 0 {
+			lErrs = errors.Append(lErrs, errors.NewMissingFieldError(attrImageStackID))
+		} else {
+			lErrs = errors.Append(lErrs, validateImageStackRef(m, path, data.LevelSet.ImageStackID))
+		}
+		dErrs = errors.Append(dErrs, errors.Wrap(lErrs, data.LevelSet))
+	}
+	names := make(map[string]struct{}, len(data.Properties))
+	for i, p := range data.Properties {
+		var pErrs error
+		if p.Name == "" {
+			pErrs = errors.Append(pErrs, errors.NewMissingFieldError(attrName))
+		} else if _, ok := names[p.Name]; ok {
+			pErrs = errors.Append(pErrs, ErrDuplicatedPropertyName)
+		}
+		names[p.Name] = struct{}{}
+		if p.ImageStackID == 0 {
+			pErrs = errors.Append(pErrs, errors.NewMissingFieldError(attrImageStackID))
+		} else {
+			pErrs = errors.Append(pErrs, validateImageStackRef(m, path, p.ImageStackID))
+		}
+		dErrs = errors.Append(dErrs, errors.WrapIndex(pErrs, p, i))
+	}
+	if dErrs != nil {
+		errs = errors.Append(errs, errors.Wrap(errors.Wrap(dErrs, data), obj.Mesh))
+	}
+	return errs
+}
+
+func validateImageStackRef(m *go3mf.Model, path string, id uint32) error {
+	if a, ok := m.FindAsset(path, id); ok {
+		if _, ok := a.(*ImageStack); ok {
+			return nil
+		}
+	}
+	return ErrImageStackRef
+}
@@ -0,0 +1,87 @@
+package volumetric
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/errors"
+)
+
+func TestValidate(t *testing.T) {
+	validMesh := func(data *VolumetricData) *go3mf.Mesh {
+		return &go3mf.Mesh{
+			Vertices: []go3mf.Point3D{{0, 0, 0}, {10, 0, 0}, {0, 10, 0}, {0, 0, 10}},
+			Triangles: []go3mf.Triangle{
+				go3mf.NewTriangle(0, 1, 2), go3mf.NewTriangle(0, 1, 3),
+				go3mf.NewTriangle(0, 2, 3), go3mf.NewTriangle(1, 2, 3),
+			},
+			Any: go3mf.Any{data},
+		}
+	}
+	tests := []struct {
+		name  string
+		model *go3mf.Model
+		want  []string
+	}{
+		{"empty resources", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&ImageStack{},
+		}}}, []string{
+			fmt.Sprintf("Resources@ImageStack#0: %v", errors.ErrMissingID),
+			fmt.Sprintf("Resources@ImageStack#0: %v", &errors.MissingFieldError{Name: attrRowCount}),
+			fmt.Sprintf("Resources@ImageStack#0: %v", &errors.MissingFieldError{Name: attrColumnCount}),
+			fmt.Sprintf("Resources@ImageStack#0: %v", &errors.MissingFieldError{Name: attrSheetCount}),
+		}},
+		{"invalid sheets", &go3mf.Model{Resources: go3mf.Resources{Assets: []go3mf.Asset{
+			&ImageStack{ID: 1, RowCount: 1, ColumnCount: 1, SheetCount: 3, Sheets: []ImageSheet{
+				{Path: "/a.png"}, {}, {Path: "/b.png"}, {Path: "/A.PNG"},
+			}},
+		}}, Attachments: []go3mf.Attachment{{Path: "/a.png"}}}, []string{
+			fmt.Sprintf("Resources@ImageStack#0: %v", ErrSheetCount),
+			fmt.Sprintf("Resources@ImageStack#0@ImageSheet#1: %v", &errors.MissingFieldError{Name: attrPath}),
+			fmt.Sprintf("Resources@ImageStack#0@ImageSheet#2: %v", ErrMissingSheetPart),
+		}},
+		{"invalid object", &go3mf.Model{Resources: go3mf.Resources{
+			Assets: []go3mf.Asset{
+				&ImageStack{ID: 1, RowCount: 1, ColumnCount: 1, SheetCount: 1, Sheets: []ImageSheet{{Path: "/a.png"}}},
+			},
+			Objects: []*go3mf.Object{
+				{ID: 2, Type: go3mf.ObjectTypeSupport, Mesh: validMesh(&VolumetricData{LevelSet: &LevelSet{ImageStackID: 1}})},
+				{ID: 3, Mesh: validMesh(&VolumetricData{LevelSet: &LevelSet{ImageStackID: 2}})},
+				{ID: 4, Mesh: validMesh(&VolumetricData{LevelSet: &LevelSet{}})},
+				{ID: 5, Mesh: validMesh(&VolumetricData{Properties: []PropertyChannel{
+					{Name: "a", ImageStackID: 1},
+					{ImageStackID: 1},
+					{Name: "a", ImageStackID: 100},
+					{Name: "b"},
+				}})},
+				{ID: 6, Type: go3mf.ObjectTypeSolidSupport, Mesh: validMesh(&VolumetricData{LevelSet: &LevelSet{ImageStackID: 1}})},
+			},
+		}, Attachments: []go3mf.Attachment{{Path: "/a.png"}}}, []string{
+			fmt.Sprintf("Resources@Object#0: %v", ErrVolumetricObjType),
+			fmt.Sprintf("Resources@Object#1@Mesh@VolumetricData@LevelSet: %v", ErrImageStackRef),
+			fmt.Sprintf("Resources@Object#2@Mesh@VolumetricData@LevelSet: %v", &errors.MissingFieldError{Name: attrImageStackID}),
+			fmt.Sprintf("Resources@Object#3@Mesh@VolumetricData@PropertyChannel#1: %v", &errors.MissingFieldError{Name: attrName}),
+			fmt.Sprintf("Resources@Object#3@Mesh@VolumetricData@PropertyChannel#2: %v", ErrDuplicatedPropertyName),
+			fmt.Sprintf("Resources@Object#3@Mesh@VolumetricData@PropertyChannel#2: %v", ErrImageStackRef),
+			fmt.Sprintf("Resources@Object#3@Mesh@VolumetricData@PropertyChannel#3: %v", &errors.MissingFieldError{Name: attrImageStackID}),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.Extensions = []go3mf.Extension{DefaultExtension}
+			err := tt.model.Validate()
+			if err == nil {
+				t.Fatal("error expected")
+			}
+			var errs []string
+			for _, err := range err.(*errors.List).Errors {
+				errs = append(errs, err.Error())
+			}
+			if diff := deep.Equal(errs, tt.want); diff != nil {
+				t.Errorf("Validate() = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package volumetric
+
+import (
+	"errors"
+
+	"github.com/qmuntal/go3mf"
+)
+
+const (
+	// Namespace is the canonical name of this extension.
+	Namespace = "http://schemas.microsoft.com/3dmanufacturing/volumetric/2022/01"
+	// RelTypeTexture3D is the canonical 3D texture relationship type.
+	RelTypeTexture3D = "http://schemas.microsoft.com/3dmanufacturing/2013/01/3dtexture"
+)
+
+var DefaultExtension = go3mf.Extension{
+	Namespace:  Namespace,
+	LocalName:  "v",
+	IsRequired: false,
+}
+
+var (
+	ErrMissingSheetPart       = errors.New("image sheet part MUST be added as an attachment")
+	ErrSheetCount             = errors.New("sheetcount MUST match the number of image sheets")
+	ErrImageStackRef          = errors.New("imagestackid MUST reference an imagestack resource")
+	ErrVolumetricObjType      = errors.New("volumetric data MUST only be added to a mesh object of type model or solidsupport")
+	ErrDuplicatedPropertyName = errors.New("property names MUST be unique within a volumetricdata")
+)
+
+func init() {
+	go3mf.Register(Namespace, Spec{})
+}
+
+type Spec struct{}
+
+// Channel defines the image channel used to sample an image stack.
+type Channel uint8
+
+// Supported channels.
+const (
+	ChannelR Channel = iota
+	ChannelG
+	ChannelB
+	ChannelA
+)
+
+func newChannel(s string) (c Channel, ok bool) {
+	c, ok = map[string]Channel{
+		"R": ChannelR,
+		"G": ChannelG,
+		"B": ChannelB,
+		"A": ChannelA,
+	}[s]
+	return
+}
+
+func (c Channel) String() string {
+	return map[Channel]string{
+		ChannelR: "R",
+		ChannelG: "G",
+		ChannelB: "B",
+		ChannelA: "A",
+	}[c]
+}
+
+// ImageSheet references a PNG image that defines
+// one of the sheets of an image stack.
+type ImageSheet struct {
+	Path string
+}
+
+// ImageStack defines a 3D voxel image as a stack of 2D images.
+// All the sheets share the same number of rows and columns.
+type ImageStack struct {
+	ID          uint32
+	RowCount    uint32
+	ColumnCount uint32
+	SheetCount  uint32
+	Sheets      []ImageSheet
+}
+
+// Identify returns the unique ID of the resource.
+func (r *ImageStack) Identify() uint32 {
+	return r.ID
+}
+
+// LevelSet defines the boundary of the object as the iso-surface
+// of an image stack channel at SolidThreshold.
+// Transform maps the object coordinates to the normalized image stack coordinates.
+type LevelSet struct {
+	ImageStackID   uint32
+	Channel        Channel
+	Transform      go3mf.Matrix
+	SolidThreshold float32
+	MinFeatureSize float32
+	FallbackValue  float32
+	MeshBBoxOnly   bool
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (l *LevelSet) HasTransform() bool {
+	return hasTransform(l.Transform)
+}
+
+// PropertyChannel maps a named property to an image stack channel.
+// Transform maps the object coordinates to the normalized image stack coordinates.
+type PropertyChannel struct {
+	Name         string
+	ImageStackID uint32
+	Channel      Channel
+	Transform    go3mf.Matrix
+	Required     bool
+}
+
+// HasTransform returns true if the transform is different than the identity.
+func (p *PropertyChannel) HasTransform() bool {
+	return hasTransform(p.Transform)
+}
+
+// VolumetricData defines the volumetric content of a mesh.
+type VolumetricData struct {
+	LevelSet   *LevelSet
+	Properties []PropertyChannel
+}
+
+// GetVolumetricData returns the volumetric data of the mesh, if any.
+func GetVolumetricData(mesh *go3mf.Mesh) *VolumetricData {
+	for _, a := range mesh.Any {
+		if a, ok := a.(*VolumetricData); ok {
+			return a
+		}
+	}
+	return nil
+}
+
+func hasTransform(t go3mf.Matrix) bool {
+	return t != go3mf.Matrix{} && t != go3mf.Identity()
+}
+
+const (
+	attrImageStack     = "imagestack"
+	attrImageSheet     = "imagesheet"
+	attrVolumetricData = "volumetricdata"
+	attrLevelSet       = "levelset"
+	attrProperty       = "property"
+	attrID             = "id"
+	attrRowCount       = "rowcount"
+	attrColumnCount    = "columncount"
+	attrSheetCount     = "sheetcount"
+	attrPath           = "path"
+	attrImageStackID   = "imagestackid"
+	attrChannel        = "channel"
+	attrTransform      = "transform"
+	attrSolidThreshold = "solidthreshold"
+	attrMinFeatureSize = "minfeaturesize"
+	attrFallbackValue  = "fallbackvalue"
+	attrMeshBBoxOnly   = "meshbboxonly"
+	attrName           = "name"
+	attrRequired       = "required"
+)
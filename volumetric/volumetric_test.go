@@ -0,0 +1,182 @@
+package volumetric
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qmuntal/go3mf"
+	"github.com/qmuntal/go3mf/spec"
+)
+
+var _ spec.Marshaler = new(ImageStack)
+var _ spec.Marshaler = new(VolumetricData)
+var _ go3mf.Asset = new(ImageStack)
+
+func TestChannel_String(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Channel
+	}{
+		{"R", ChannelR},
+		{"G", ChannelG},
+		{"B", ChannelB},
+		{"A", ChannelA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.String(); got != tt.name {
+				t.Errorf("Channel.String() = %v, want %v", got, tt.name)
+			}
+		})
+	}
+}
+
+func Test_newChannel(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantC  Channel
+		wantOk bool
+	}{
+		{"R", ChannelR, true},
+		{"G", ChannelG, true},
+		{"B", ChannelB, true},
+		{"A", ChannelA, true},
+		{"empty", ChannelR, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, gotOk := newChannel(tt.name)
+			if !reflect.DeepEqual(gotC, tt.wantC) {
+				t.Errorf("newChannel() gotC = %v, want %v", gotC, tt.wantC)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("newChannel() gotOk = %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestLevelSet_HasTransform(t *testing.T) {
+	tests := []struct {
+		name string
+		t    go3mf.Matrix
+		want bool
+	}{
+		{"zero", go3mf.Matrix{}, false},
+		{"identity", go3mf.Identity(), false},
+		{"base", go3mf.Identity().Translate(1, 0, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := &LevelSet{Transform: tt.t}
+			if got := ls.HasTransform(); got != tt.want {
+				t.Errorf("LevelSet.HasTransform() = %v, want %v", got, tt.want)
+			}
+			p := &PropertyChannel{Transform: tt.t}
+			if got := p.HasTransform(); got != tt.want {
+				t.Errorf("PropertyChannel.HasTransform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetVolumetricData(t *testing.T) {
+	data := new(VolumetricData)
+	tests := []struct {
+		name string
+		mesh *go3mf.Mesh
+		want *VolumetricData
+	}{
+		{"empty", &go3mf.Mesh{}, nil},
+		{"other", &go3mf.Mesh{Any: go3mf.Any{nil}}, nil},
+		{"base", &go3mf.Mesh{Any: go3mf.Any{nil, data}}, data},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetVolumetricData(tt.mesh); got != tt.want {
+				t.Errorf("GetVolumetricData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  func() spec.Cloner
+		mutate func(interface{})
+	}{
+		{"imagestack", func() spec.Cloner {
+			return &ImageStack{ID: 1, RowCount: 2, ColumnCount: 2, SheetCount: 1, Sheets: []ImageSheet{{Path: "/3D/a.png"}}}
+		}, func(c interface{}) { c.(*ImageStack).Sheets[0].Path = "/3D/b.png" }},
+		{"data", func() spec.Cloner {
+			return &VolumetricData{LevelSet: &LevelSet{ImageStackID: 1, Transform: go3mf.Identity()}, Properties: []PropertyChannel{{Name: "a", ImageStackID: 1}}}
+		}, func(c interface{}) {
+			c.(*VolumetricData).LevelSet.ImageStackID = 2
+			c.(*VolumetricData).Properties[0].Name = "b"
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			got := v.Clone()
+			if !reflect.DeepEqual(got, v) {
+				t.Fatalf("%T.Clone() = %v, want %v", v, got, v)
+			}
+			tt.mutate(got)
+			if want := tt.value(); !reflect.DeepEqual(v, want) {
+				t.Errorf("%T.Clone() shares data with the original, got %v, want %v", v, v, want)
+			}
+		})
+	}
+}
+
+func TestRemap(t *testing.T) {
+	ids := func(id uint32) uint32 { return id + 10 }
+	paths := func(p string) string { return p + "_1" }
+	tests := []struct {
+		name string
+		v    interface{}
+		want interface{}
+	}{
+		{"imagestack", &ImageStack{ID: 1, Sheets: []ImageSheet{{Path: "/a.png"}}}, &ImageStack{ID: 11, Sheets: []ImageSheet{{Path: "/a.png_1"}}}},
+		{"data", &VolumetricData{LevelSet: &LevelSet{ImageStackID: 1}, Properties: []PropertyChannel{{ImageStackID: 2}}}, &VolumetricData{LevelSet: &LevelSet{ImageStackID: 11}, Properties: []PropertyChannel{{ImageStackID: 12}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if r, ok := tt.v.(spec.IDRemapper); ok {
+				r.RemapIDs(ids)
+			}
+			if r, ok := tt.v.(spec.PathRemapper); ok {
+				r.RemapPaths(paths)
+			}
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T remapped = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		name string
+		v    spec.Scaler
+		want interface{}
+	}{
+		{"volumetricdata", &VolumetricData{
+			LevelSet:   &LevelSet{MinFeatureSize: 1, Transform: go3mf.Identity().Translate(1, 0, 0)},
+			Properties: []PropertyChannel{{}},
+		}, &VolumetricData{
+			LevelSet:   &LevelSet{MinFeatureSize: 2, Transform: go3mf.Matrix{0.5, 0, 0, 0, 0, 0.5, 0, 0, 0, 0, 0.5, 0, 1, 0, 0, 1}},
+			Properties: []PropertyChannel{{Transform: go3mf.Matrix{0.5, 0, 0, 0, 0, 0.5, 0, 0, 0, 0, 0.5, 0, 0, 0, 0, 1}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.v.Scale(2)
+			if !reflect.DeepEqual(tt.v, tt.want) {
+				t.Errorf("%T.Scale() = %v, want %v", tt.v, tt.v, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package go3mf
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"io"
+	"time"
+)
+
+// zipModTime is the modification time of every deterministic zip entry,
+// which is the earliest time representable in the zip format.
+var zipModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newZipWriter returns a packageWriter that produces the same
+// zip bytes every time the same parts are written.
+func newZipWriter(w io.Writer) *fileWriter {
+	zw := zip.NewWriter(w)
+	return &fileWriter{
+		create: func(name string, c Compression) (io.Writer, error) {
+			fh := &zip.FileHeader{
+				Name:     name[1:], // remove first slash
+				Method:   zip.Deflate,
+				Modified: zipModTime,
+			}
+			if c == CompressionNone {
+				fh.Method = zip.Store
+			} else {
+				level := zipCompressionLevel(c)
+				zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+					return flate.NewWriter(out, level)
+				})
+			}
+			return zw.CreateHeader(fh)
+		},
+		close: zw.Close,
+	}
+}
+
+func zipCompressionLevel(c Compression) int {
+	switch c {
+	case CompressionMaximum:
+		return flate.BestCompression
+	case CompressionFast, CompressionSuperFast:
+		return flate.BestSpeed
+	}
+	return flate.DefaultCompression
+}